@@ -0,0 +1,189 @@
+package comparer
+
+import "reflect"
+
+// A KeyFunc returns the identity of a value, like its ID. Two values with equal keys are the same entity, even when they have other differences.
+type KeyFunc func(v interface{}) interface{}
+
+type alignment struct {
+	limit int
+	key   KeyFunc
+	paths scope
+}
+
+// AlignSlices returns a new Config that aligns the elements of the slices when the differences are collected, instead of comparing them index by index.
+// The elements are aligned with the shortest edit script, so an inserted element is reported as added instead of changing all the following elements.
+// If no paths are provided, it applies to all the slices.
+//
+// If the KeyFunc key is nil, the aligned elements are the equal ones, and the removed elements followed by added elements are paired as modified elements.
+// Otherwise, the aligned elements are the ones with equal keys, and they are compared to find their differences.
+//
+// The limit is the maximum number of removed and added elements, that bounds the cost of the alignment. When it is exceeded, the elements are compared
+// index by index. If the limit is 0 or negative, it is 1000. The result of the comparison does not change, only the differences that are collected.
+//
+// The added elements have the index in the second value, and the other elements have the index in the first value.
+func AlignSlices(limit int, key KeyFunc, paths ...string) Config {
+	return func(comp *Comparer) {
+		comp.aligns = append(comp.aligns, alignment{limit: limit, key: key, paths: paths})
+	}
+}
+
+// defaultAlignLimit is the limit of the alignments without a positive limit.
+const defaultAlignLimit = 1000
+
+// An edit is an operation of an edit script. The index i is -1 for the added elements, and j is -1 for the removed elements.
+type edit struct {
+	i, j int
+}
+
+// align compares the slices of the node with the edit script, if an alignment applies to the node.
+func (c *Comparer) align(n *Node) (bool, bool) {
+	var al *alignment
+	for i := range c.aligns {
+		if c.aligns[i].paths.match(n.Path) {
+			al = &c.aligns[i]
+		}
+	}
+	if al == nil {
+		return false, false
+	}
+
+	a, b := n.A, n.B
+	same := func(i, j int) bool {
+		if al.key != nil {
+			return reflect.DeepEqual(al.key(c.value(a.Index(i))), al.key(c.value(b.Index(j))))
+		}
+		probe := n.indexChild(i, a.Index(i), b.Index(j))
+		probe.state = n.probe()
+		return c.equal(probe)
+	}
+	limit := al.limit
+	if limit <= 0 {
+		limit = defaultAlignLimit
+	}
+	edits, ok := shortestEdit(a.Len(), b.Len(), same, limit)
+	if !ok {
+		return false, false
+	}
+	if al.key == nil {
+		edits = pair(edits)
+	}
+
+	result := true
+	for _, e := range edits {
+		if !n.proceed(result) {
+			break
+		}
+		var m *Node
+		switch {
+		case e.j < 0:
+			m = n.indexChild(e.i, a.Index(e.i), reflect.Value{})
+		case e.i < 0:
+			m = n.indexChild(e.j, reflect.Value{}, b.Index(e.j))
+		default:
+			m = n.indexChild(e.i, a.Index(e.i), b.Index(e.j))
+		}
+		m.entry = true
+		result = c.equal(m) && result
+	}
+	return result, true
+}
+
+// shortestEdit returns the shortest edit script that transforms a sequence of length n into a sequence of length m, with the Myers algorithm.
+// The function same reports whether the elements i and j are the same. It returns false if the script needs more than limit removals and additions.
+func shortestEdit(n int, m int, same func(i, j int) bool, limit int) ([]edit, bool) {
+	max := n + m
+	if limit < max {
+		max = limit
+	}
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	var trace [][]int
+
+	for d := 0; d <= max; d++ {
+		// The round d only reads the diagonals from -d-1 to d+1, so only them are kept for the backtracking.
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && same(x, y) {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, n, m), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// backtrack returns the edit script found by shortestEdit, from the diagonals kept in each round.
+func backtrack(trace [][]int, x int, y int) []edit {
+	var edits []edit
+	for d := len(trace) - 1; d >= 0; d-- {
+		// The diagonal k of the round d is at the position k+d+1.
+		v, offset := trace[d], d+1
+		k := x - y
+		var prev int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prev = k + 1
+		} else {
+			prev = k - 1
+		}
+		px := v[offset+prev]
+		py := px - prev
+		for x > px && y > py {
+			edits = append(edits, edit{x - 1, y - 1})
+			x, y = x-1, y-1
+		}
+		if d > 0 {
+			if x == px {
+				edits = append(edits, edit{-1, y - 1})
+			} else {
+				edits = append(edits, edit{x - 1, -1})
+			}
+		}
+		x, y = px, py
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// pair joins the removed and added elements between the same aligned elements, so they are compared as modified elements.
+func pair(edits []edit) []edit {
+	var result []edit
+	for i := 0; i < len(edits); {
+		if edits[i].i >= 0 && edits[i].j >= 0 {
+			result = append(result, edits[i])
+			i++
+			continue
+		}
+		var removed, added []int
+		for ; i < len(edits) && (edits[i].i < 0 || edits[i].j < 0); i++ {
+			if edits[i].j < 0 {
+				removed = append(removed, edits[i].i)
+			} else {
+				added = append(added, edits[i].j)
+			}
+		}
+		for len(removed) > 0 && len(added) > 0 {
+			result = append(result, edit{removed[0], added[0]})
+			removed, added = removed[1:], added[1:]
+		}
+		for _, r := range removed {
+			result = append(result, edit{r, -1})
+		}
+		for _, a := range added {
+			result = append(result, edit{-1, a})
+		}
+	}
+	return result
+}
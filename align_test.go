@@ -0,0 +1,131 @@
+package comparer_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es14 struct {
+	ID   int
+	Name string
+}
+
+func TestAlignSlices(t *testing.T) {
+	id := func(v interface{}) interface{} {
+		return v.(es14).ID
+	}
+
+	cases := map[string]struct {
+		config   comparer.Config
+		a        interface{}
+		b        interface{}
+		expected []comparer.Difference
+	}{
+		"Insert": {
+			comparer.AlignSlices(0, nil),
+			[]int{1, 2, 3, 4},
+			[]int{0, 1, 2, 3, 4},
+			[]comparer.Difference{
+				{Path: "[0]", Change: comparer.Added, A: nil, B: 0},
+			},
+		},
+		"Delete": {
+			comparer.AlignSlices(0, nil),
+			[]int{1, 2, 3, 4},
+			[]int{1, 3, 4},
+			[]comparer.Difference{
+				{Path: "[1]", Change: comparer.Removed, A: 2, B: nil},
+			},
+		},
+		"Modify": {
+			comparer.AlignSlices(0, nil),
+			[]int{1, 2, 3, 4},
+			[]int{0, 1, 5, 3},
+			[]comparer.Difference{
+				{Path: "[0]", Change: comparer.Added, A: nil, B: 0},
+				{Path: "[1]", Change: comparer.Modified, A: 2, B: 5},
+				{Path: "[3]", Change: comparer.Removed, A: 4, B: nil},
+			},
+		},
+		"Limit": {
+			comparer.AlignSlices(1, nil),
+			[]int{1, 2, 3},
+			[]int{0, 1, 2, 4},
+			[]comparer.Difference{
+				{Path: "[0]", Change: comparer.Modified, A: 1, B: 0},
+				{Path: "[1]", Change: comparer.Modified, A: 2, B: 1},
+				{Path: "[2]", Change: comparer.Modified, A: 3, B: 2},
+				{Path: "[3]", Change: comparer.Added, A: nil, B: 4},
+			},
+		},
+		"Key": {
+			comparer.AlignSlices(0, id),
+			[]es14{{1, "a"}, {2, "b"}, {3, "c"}},
+			[]es14{{2, "x"}, {3, "c"}, {4, "d"}},
+			[]comparer.Difference{
+				{Path: "[0]", Change: comparer.Removed, A: es14{1, "a"}, B: nil},
+				{Path: "[1].Name", Change: comparer.Modified, A: "b", B: "x"},
+				{Path: "[2]", Change: comparer.Added, A: nil, B: es14{4, "d"}},
+			},
+		},
+		"Path": {
+			comparer.AlignSlices(0, nil, "[B]"),
+			map[string][]int{"A": {1, 2}, "B": {1, 2}},
+			map[string][]int{"A": {0, 1, 2}, "B": {0, 1, 2}},
+			[]comparer.Difference{
+				{Path: "[A][0]", Change: comparer.Modified, A: 1, B: 0},
+				{Path: "[A][1]", Change: comparer.Modified, A: 2, B: 1},
+				{Path: "[A][2]", Change: comparer.Added, A: nil, B: 2},
+				{Path: "[B][0]", Change: comparer.Added, A: nil, B: 0},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := comparer.New(tc.config)
+			diffs := c.Diff(tc.a, tc.b)
+			if !reflect.DeepEqual(summary(diffs), tc.expected) {
+				t.Errorf("The differences should be %+v, got %+v", tc.expected, summary(diffs))
+			}
+			if c.Equal(tc.a, tc.b) {
+				t.Errorf("The values should not be equal")
+			}
+
+			patched := reflect.New(reflect.TypeOf(tc.a))
+			patched.Elem().Set(reflect.ValueOf(tc.a))
+			if err := comparer.Patch(patched.Interface(), diffs); err != nil {
+				t.Fatalf("The differences should be applied, got %v", err)
+			}
+			if !comparer.New().Equal(patched.Elem().Interface(), tc.b) {
+				t.Errorf("The patched value should be %v, got %v", tc.b, patched.Elem().Interface())
+			}
+		})
+	}
+}
+
+func TestAlignSlicesDefaultLimit(t *testing.T) {
+	c := comparer.New(comparer.AlignSlices(0, nil))
+
+	a := make([]int, 5000)
+	for i := range a {
+		a[i] = i
+	}
+	b := append([]int{-1}, a...)
+	diffs := c.Diff(a, b)
+	if len(diffs) != 1 || diffs[0].Path != "[0]" || diffs[0].Change != comparer.Added {
+		t.Errorf("The long slices should be aligned, got %d differences", len(diffs))
+	}
+
+	b = make([]int, 1000)
+	for i := range b {
+		b[i] = -i - 1
+	}
+	identity := func(v interface{}) interface{} { return v }
+	diffs = comparer.New(comparer.AlignSlices(0, identity)).Diff(a[:1000], b)
+	if len(diffs) != 1000 || diffs[0].Path != "[0]" || diffs[0].Change != comparer.Modified {
+		t.Errorf("The slices over the default limit should be compared index by index, got %d differences", len(diffs))
+	}
+}
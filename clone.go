@@ -0,0 +1,155 @@
+package comparer
+
+import "reflect"
+
+// A Cloner returns a copy of the value v.
+type Cloner func(v interface{}) interface{}
+
+// TypeCloner returns a new Config that uses the Cloner c to copy the values of type t in the Clone method.
+// The value returned by the Cloner must be assignable to the type t, or nil to leave the copy zero.
+func TypeCloner(t reflect.Type, c Cloner) Config {
+	return func(comp *Comparer) {
+		if comp.cloners == nil {
+			comp.cloners = map[reflect.Type]Cloner{}
+		}
+		comp.cloners[t] = c
+	}
+}
+
+// Clone returns a deep copy of v, made with a default Comparer.
+func Clone(v interface{}) interface{} {
+	return New().Clone(v)
+}
+
+// Clone returns a deep copy of v, that does not share its pointers, slices, maps and interfaces. The unexported fields are copied as they are,
+// so the copy shares their references, because they may depend on them, like the location of a time.Time. A TypeCloner copies them deeply.
+// The shared and cyclic references of v are shared and cyclic in the copy too. The functions and the channels are not copied.
+//
+// The struct fields and the map entries ignored by the configuration, like IgnoreFields or IgnoreMapEntries, are not copied, and the values
+// with a TypeCloner are copied by it. The copy is equal to v for the Equal method of c, as long as v does not have cycles and the TypeCloners
+// return equal values.
+func (c *Comparer) Clone(v interface{}) interface{} {
+	a := reflect.ValueOf(v)
+	if !a.IsValid() {
+		return nil
+	}
+	n := c.root(EqualOperation, nil, nil)
+	n.A, n.B = reflect.New(a.Type()).Elem(), reflect.New(a.Type()).Elem()
+	n.A.Set(a)
+
+	cl := &cloning{comparer: c, visited: map[visit]reflect.Value{}}
+	cl.clone(n)
+	return n.B.Interface()
+}
+
+// cloning holds the references already copied by a Clone call.
+type cloning struct {
+	comparer *Comparer
+	visited  map[visit]reflect.Value
+}
+
+// visit identifies a copied pointer, slice or map.
+type visit struct {
+	p   uintptr
+	t   reflect.Type
+	len int
+}
+
+// clone copies the value A of the node to its value B. Both values must be addressable.
+func (cl *cloning) clone(n *Node) {
+	c := cl.comparer
+	a, b := writable(n.A), writable(n.B)
+	n.A, n.B = a, b
+	if f, ok := c.cloners[a.Type()]; ok {
+		if v := reflect.ValueOf(f(a.Interface())); v.IsValid() {
+			b.Set(v)
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			cl.clone(n.index(i))
+		}
+	case reflect.Interface:
+		if a.IsNil() {
+			return
+		}
+		e := reflect.New(a.Elem().Type()).Elem()
+		cl.clone(n.elem(copyOf(a.Elem()), e))
+		b.Set(e)
+	case reflect.Map:
+		if a.IsNil() {
+			return
+		}
+		k := visit{a.Pointer(), a.Type(), 0}
+		if v, ok := cl.visited[k]; ok {
+			b.Set(v)
+			return
+		}
+		b.Set(reflect.MakeMapWithSize(a.Type(), a.Len()))
+		cl.visited[k] = b
+
+		for _, key := range n.keys(a) {
+			value := a.MapIndex(key)
+			if c.ignoreEntry(key, value, value) {
+				continue
+			}
+			ck, cv := reflect.New(key.Type()).Elem(), reflect.New(value.Type()).Elem()
+			m := n.key(key)
+			cl.clone(m.elem(copyOf(key), ck))
+			cl.clone(m.elem(copyOf(value), cv))
+			b.SetMapIndex(ck, cv)
+		}
+	case reflect.Ptr:
+		if a.IsNil() {
+			return
+		}
+		k := visit{a.Pointer(), a.Type(), 0}
+		if v, ok := cl.visited[k]; ok {
+			b.Set(v)
+			return
+		}
+		p := reflect.New(a.Type().Elem())
+		b.Set(p)
+		cl.visited[k] = p
+		cl.clone(n.elem(a.Elem(), p.Elem()))
+	case reflect.Slice:
+		if a.IsNil() {
+			return
+		}
+		k := visit{a.Pointer(), a.Type(), a.Len()}
+		if v, ok := cl.visited[k]; ok {
+			b.Set(v)
+			return
+		}
+		b.Set(reflect.MakeSlice(a.Type(), a.Len(), a.Len()))
+		cl.visited[k] = b
+		for i := 0; i < a.Len(); i++ {
+			cl.clone(n.index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			f := a.Type().Field(i)
+			if c.ignoreField(f, a.Field(i), a.Field(i)) {
+				continue
+			}
+			if f.PkgPath != "" {
+				// The unexported fields hold the internals of their types, like the location of a time.Time, that may rely on their pointers.
+				writable(b.Field(i)).Set(writable(a.Field(i)))
+				continue
+			}
+			cl.clone(n.field(i))
+		}
+	default:
+		b.Set(a)
+	}
+}
+
+// copyOf returns an addressable copy of the value v.
+func copyOf(v reflect.Value) reflect.Value {
+	w := reflect.New(v.Type()).Elem()
+	w.Set(v)
+	return w
+}
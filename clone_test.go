@@ -0,0 +1,161 @@
+package comparer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es18 struct {
+	Name     string
+	Tags     []string
+	Scores   map[string][]int
+	Child    *es18
+	Extra    interface{}
+	Created  time.Time
+	Array    [2]*int
+	Callback func() int
+	private  []int
+}
+
+func TestClone(t *testing.T) {
+	one := 1
+	cases := map[string]interface{}{
+		"Nil":        nil,
+		"Int":        42,
+		"String":     "a",
+		"NilSlice":   []int(nil),
+		"Slice":      []int{1, 2},
+		"Map":        map[string]int{"a": 1},
+		"Pointer":    &es1{1, "a"},
+		"Interfaces": []interface{}{1, "a", &es1{2, "b"}},
+		"Struct": es18{
+			Name:    "a",
+			Tags:    []string{"x", "y"},
+			Scores:  map[string][]int{"a": {1, 2}},
+			Child:   &es18{Name: "b", Extra: map[string]interface{}{"c": []int{3}}},
+			Extra:   &es1{1, "a"},
+			Created: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			Array:   [2]*int{&one, nil},
+			private: []int{4, 5},
+		},
+		"Now":       es18{Created: time.Now(), private: []int{1}},
+		"MapValue":  map[string]es18{"a": {Created: time.Now(), private: []int{1}}},
+		"Interface": []interface{}{es18{Created: time.Now(), private: []int{1}}},
+	}
+
+	c := comparer.New()
+	for name, v := range cases {
+		t.Run(name, func(t *testing.T) {
+			clone := comparer.Clone(v)
+			if !c.Equal(v, clone) {
+				t.Errorf("The clone should be equal to the value, got %#v", clone)
+			}
+			if !reflect.DeepEqual(v, clone) {
+				t.Errorf("The clone should be deeply equal to the value, got %#v", clone)
+			}
+		})
+	}
+}
+
+func TestCloneIndependent(t *testing.T) {
+	v := &es18{Tags: []string{"x"}, Scores: map[string][]int{"a": {1}}, Extra: []int{2}, Child: &es18{Name: "b"}, private: []int{3}}
+	clone := comparer.Clone(v).(*es18)
+
+	clone.Tags[0] = "y"
+	clone.Scores["a"][0] = 0
+	clone.Extra.([]int)[0] = 0
+	clone.Child.Name = "c"
+	expected := &es18{Tags: []string{"x"}, Scores: map[string][]int{"a": {1}}, Extra: []int{2}, Child: &es18{Name: "b"}, private: []int{3}}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("The value should not share its references with the clone, got %#v", v)
+	}
+	if &clone.private[0] != &v.private[0] {
+		t.Errorf("The unexported fields should be copied as they are")
+	}
+}
+
+func TestCloneTime(t *testing.T) {
+	now := time.Now()
+	cases := map[string]time.Time{
+		"Local": now,
+		"UTC":   now.UTC(),
+		"Fixed": now.In(time.FixedZone("X", 3600)),
+	}
+
+	for name, v := range cases {
+		t.Run(name, func(t *testing.T) {
+			if c := comparer.New(); !c.Equal(es18{Created: v}, comparer.Clone(es18{Created: v})) || c.Equal(es18{Created: v}, es18{Created: v.Add(1)}) {
+				t.Errorf("The clone should be equal to the time, and only to it")
+			}
+			clone := comparer.Clone(es18{Created: v}).(es18).Created
+			if clone.Location() != v.Location() {
+				t.Errorf("The location should be kept, got %v", clone.Location())
+			}
+			if clone.Format(time.RFC3339Nano) != v.Format(time.RFC3339Nano) || !clone.Equal(v) {
+				t.Errorf("The time should be kept, got %v", clone)
+			}
+		})
+	}
+	if clone := comparer.Clone(now).(time.Time); clone.Location() != time.Local || clone != now {
+		t.Errorf("The time should round trip, got %v", clone)
+	}
+}
+
+func TestCloneReferences(t *testing.T) {
+	shared := &es18{Name: "shared"}
+	v := &es18{Name: "a", Child: shared, Extra: shared}
+	clone := comparer.Clone(v).(*es18)
+	if clone.Child != clone.Extra || clone.Child == shared {
+		t.Errorf("The shared references should be shared in the clone")
+	}
+
+	cycle := &es18{Name: "a"}
+	cycle.Child = &es18{Name: "b", Child: cycle}
+	clone = comparer.Clone(cycle).(*es18)
+	if clone.Child.Child != clone || clone == cycle {
+		t.Errorf("The cycles should be cycles in the clone")
+	}
+
+	m := map[string]interface{}{}
+	m["self"] = m
+	cm := comparer.Clone(m).(map[string]interface{})
+	if reflect.ValueOf(cm["self"]).Pointer() != reflect.ValueOf(cm).Pointer() {
+		t.Errorf("The cyclic maps should be cyclic in the clone")
+	}
+}
+
+func TestCloneConfigs(t *testing.T) {
+	c := comparer.New(
+		comparer.IgnoreFields("Created"),
+		comparer.IgnoreMapEntries(func(key, _ interface{}) bool { return strings.HasPrefix(key.(string), "_") }),
+		comparer.TypeCloner(reflect.TypeOf(""), func(v interface{}) interface{} { return v.(string) }),
+		comparer.TypeCloner(reflect.TypeOf([]int(nil)), func(v interface{}) interface{} { return nil }),
+	)
+
+	v := es18{Name: "a", Created: time.Now(), Scores: map[string][]int{"a": {1}, "_b": nil}, Tags: []string{"x"}}
+	clone := c.Clone(v).(es18)
+	if !clone.Created.IsZero() {
+		t.Errorf("The ignored fields should not be copied, got %v", clone.Created)
+	}
+	if _, ok := clone.Scores["_b"]; ok || len(clone.Scores) != 1 {
+		t.Errorf("The ignored map entries should not be copied, got %v", clone.Scores)
+	}
+	if clone.Scores["a"] != nil {
+		t.Errorf("The cloners should copy their types, got %v", clone.Scores)
+	}
+	if clone.Name != "a" || clone.Tags[0] != "x" {
+		t.Errorf("The other values should be copied, got %#v", clone)
+	}
+
+	upper := c.With(comparer.TypeCloner(reflect.TypeOf(""), func(v interface{}) interface{} { return strings.ToUpper(v.(string)) }))
+	if clone := upper.Clone(v).(es18); clone.Name != "A" || clone.Tags[0] != "X" {
+		t.Errorf("The derived comparer should use its cloner, got %#v", clone)
+	}
+	if clone := c.Clone(v).(es18); clone.Name != "a" {
+		t.Errorf("The base comparer should not be changed, got %#v", clone)
+	}
+}
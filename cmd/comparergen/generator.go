@@ -0,0 +1,514 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// options holds the configuration of the generated functions.
+type options struct {
+	types      []string
+	ignore     []string
+	unexported bool
+	bytes      bool
+}
+
+// generator writes the code of the functions that compare the types of a package.
+type generator struct {
+	options
+	pkg      string
+	specs    map[string]*ast.TypeSpec
+	matchers map[string]bool
+	comparer string
+	imports  map[string]bool
+	queue    []string
+	queued   map[string]bool
+	buf      bytes.Buffer
+}
+
+// basic lists the predeclared types that are compared with the == operator.
+var basic = map[string]bool{
+	"bool": true, "string": true, "int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true, "byte": true, "rune": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// ordered lists the predeclared types that a Comparer orders.
+var ordered = map[string]bool{
+	"string": true, "int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "byte": true, "rune": true,
+	"float32": true, "float64": true,
+}
+
+// generate parses the package in the directory dir, and returns the code of the functions for the types of the options and the code of their test.
+func generate(dir string, o options) ([]byte, []byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, nil, err
+	} else if len(pkgs) != 1 {
+		return nil, nil, fmt.Errorf("%d packages found in %s", len(pkgs), dir)
+	}
+
+	g := &generator{
+		options:  o,
+		specs:    map[string]*ast.TypeSpec{},
+		matchers: map[string]bool{},
+		comparer: strings.ToLower(o.types[0][:1]) + o.types[0][1:] + "Comparer",
+		imports:  map[string]bool{},
+		queued:   map[string]bool{},
+	}
+	for name, pkg := range pkgs {
+		g.pkg = name
+		g.collect(pkg)
+	}
+	for _, name := range o.types {
+		if spec, ok := g.specs[name]; !ok {
+			return nil, nil, fmt.Errorf("type %s not found in %s", name, dir)
+		} else if spec.TypeParams != nil {
+			return nil, nil, fmt.Errorf("type %s is generic", name)
+		}
+	}
+
+	code, err := g.code()
+	if err != nil {
+		return nil, nil, err
+	}
+	test, err := g.test()
+	if err != nil {
+		return nil, nil, err
+	}
+	return code, test, nil
+}
+
+// collect records the type declarations of the package and the types with a Match method.
+func (g *generator) collect(pkg *ast.Package) {
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if spec, ok := spec.(*ast.TypeSpec); ok {
+						g.specs[spec.Name.Name] = spec
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil || len(decl.Recv.List) == 0 || decl.Name.Name != "Match" {
+					continue
+				}
+				t := decl.Recv.List[0].Type
+				if star, ok := t.(*ast.StarExpr); ok {
+					t = star.X
+				}
+				if id, ok := t.(*ast.Ident); ok {
+					g.matchers[id.Name] = true
+				}
+			}
+		}
+	}
+}
+
+// code returns the formatted code of the functions.
+func (g *generator) code() ([]byte, error) {
+	var body bytes.Buffer
+	for _, name := range g.types {
+		g.exported(name)
+	}
+	for len(g.queue) > 0 {
+		name := g.queue[0]
+		g.queue = g.queue[1:]
+		g.helper(name)
+	}
+	body.Write(g.buf.Bytes())
+
+	g.buf.Reset()
+	g.printf("// Code generated by comparergen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.pkg)
+	if g.imports["bytes"] {
+		g.printf("\"bytes\"\n\n")
+	}
+	g.printf("\"github.com/gum-dev-ar/comparer\"\n)\n\n")
+	g.printf("// %s compares the values that the generated code does not compare.\n", g.comparer)
+	g.printf("var %s = comparer.New(%s)\n\n", g.comparer, strings.Join(g.configs(), ", "))
+	g.buf.Write(body.Bytes())
+	return format.Source(g.buf.Bytes())
+}
+
+// configs returns the expressions of the comparer configurations equivalent to the options.
+func (g *generator) configs() []string {
+	var configs []string
+	if len(g.ignore) > 0 {
+		names := make([]string, len(g.ignore))
+		for i, name := range g.ignore {
+			names[i] = strconv.Quote(name)
+		}
+		configs = append(configs, "comparer.IgnoreFields("+strings.Join(names, ", ")+")")
+	}
+	if g.unexported {
+		configs = append(configs, "comparer.IgnoreUnexported()")
+	}
+	if g.bytes {
+		configs = append(configs, "comparer.BytesComparator()")
+	}
+	return configs
+}
+
+// exported writes the EqualFoo and CompareFoo functions of the type name.
+func (g *generator) exported(name string) {
+	g.printf("// Equal%s reports whether a and b are equal, like the Equal method of %s.\n", name, g.comparer)
+	g.printf("func Equal%s(a, b *%s) bool {\n", name, name)
+	if g.matchers[name] {
+		g.printf("return %s.Equal(a, b)\n}\n\n", g.comparer)
+	} else {
+		g.need(name)
+		g.printf("if a == nil || b == nil {\nreturn a == b\n}\nreturn equal%s(a, b)\n}\n\n", name)
+	}
+
+	g.printf("// Compare%s compares the values of a and b, like the Compare method of %s.\n", name, g.comparer)
+	g.printf("// The values are not comparable when any of them is nil.\n")
+	g.printf("func Compare%s(a, b *%s) (int, bool) {\n", name, name)
+	t := g.underlying(&ast.Ident{Name: name})
+	if id, ok := t.(*ast.Ident); ok && ordered[id.Name] {
+		g.printf("if a == nil || b == nil {\nreturn 0, false\n} else if *a < *b {\nreturn -1, true\n} else if *a > *b {\nreturn 1, true\n}\nreturn 0, true\n}\n\n")
+	} else if elem, ok := g.elem(t); ok && g.bytes && g.byteElem(elem) {
+		g.imports["bytes"] = true
+		slice := "*a, *b"
+		if _, ok := t.(*ast.ArrayType); ok && t.(*ast.ArrayType).Len != nil {
+			slice = "(*a)[:], (*b)[:]"
+		}
+		g.printf("if a == nil || b == nil {\nreturn 0, false\n}\nreturn bytes.Compare(%s), true\n}\n\n", slice)
+	} else {
+		g.printf("return 0, false\n}\n\n")
+	}
+}
+
+// helper writes the function that reports whether the values of the type name are equal.
+func (g *generator) helper(name string) {
+	spec := g.specs[name]
+	g.printf("func equal%s(a, b *%s) bool {\n", name, name)
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		for _, f := range t.Fields.List {
+			for _, field := range fieldNames(f) {
+				if g.ignored(field) {
+					continue
+				}
+				g.equal(f.Type, "a."+field, "b."+field, 0)
+			}
+		}
+	case *ast.Ident:
+		if g.named(t.Name) {
+			g.need(t.Name)
+			g.printf("return equal%s((*%s)(a), (*%s)(b))\n}\n\n", t.Name, t.Name, t.Name)
+			return
+		}
+		g.equal(t, "(*a)", "(*b)", 0)
+	default:
+		g.equal(t, "(*a)", "(*b)", 0)
+	}
+	g.printf("return true\n}\n\n")
+}
+
+// equal writes the statements that return false when the values a and b of the type t are not equal.
+func (g *generator) equal(t ast.Expr, a string, b string, depth int) {
+	switch t := t.(type) {
+	case *ast.ParenExpr:
+		g.equal(t.X, a, b, depth)
+	case *ast.Ident:
+		if u, ok := g.underlying(t).(*ast.Ident); ok && basic[u.Name] && !g.matchers[t.Name] {
+			g.printf("if %s != %s {\nreturn false\n}\n", a, b)
+		} else if spec, ok := g.specs[t.Name]; ok && spec.Assign.IsValid() && spec.TypeParams == nil {
+			g.equal(spec.Type, a, b, depth)
+		} else if g.named(t.Name) {
+			g.need(t.Name)
+			g.printf("if !equal%s(%s, %s) {\nreturn false\n}\n", t.Name, address(a), address(b))
+		} else {
+			g.fallback(a, b)
+		}
+	case *ast.StarExpr:
+		if id, ok := t.X.(*ast.Ident); ok && g.matchers[id.Name] {
+			g.fallback(a, b)
+			return
+		}
+		g.printf("if %s == nil || %s == nil {\nif %s != %s {\nreturn false\n}\n} else {\n", a, b, a, b)
+		g.equal(t.X, "(*"+a+")", "(*"+b+")", depth)
+		g.printf("}\n")
+	case *ast.ArrayType:
+		i := "i" + strconv.Itoa(depth)
+		if g.bytes && g.byteElem(t.Elt) {
+			g.imports["bytes"] = true
+			if t.Len == nil {
+				g.printf("if !bytes.Equal(%s, %s) {\nreturn false\n}\n", a, b)
+			} else {
+				g.printf("if !bytes.Equal(%s[:], %s[:]) {\nreturn false\n}\n", a, b)
+			}
+			return
+		} else if g.bytes && g.byteKind(t.Elt) {
+			g.fallback(a, b)
+			return
+		}
+		if t.Len == nil {
+			g.printf("if (%s == nil) != (%s == nil) || len(%s) != len(%s) {\nreturn false\n}\n", a, b, a, b)
+		}
+		g.printf("for %s := range %s {\n", i, a)
+		g.equal(t.Elt, a+"["+i+"]", b+"["+i+"]", depth+1)
+		g.printf("}\n")
+	case *ast.MapType:
+		k, v, w, ok := "k"+strconv.Itoa(depth), "v"+strconv.Itoa(depth), "w"+strconv.Itoa(depth), "ok"+strconv.Itoa(depth)
+		g.printf("if (%s == nil) != (%s == nil) || len(%s) != len(%s) {\nreturn false\n}\n", a, b, a, b)
+		g.printf("for %s, %s := range %s {\n%s, %s := %s[%s]\nif !%s {\nreturn false\n}\n", k, v, a, w, ok, b, k, ok)
+		g.equal(t.Value, v, w, depth+1)
+		g.printf("}\n")
+	default:
+		g.fallback(a, b)
+	}
+}
+
+// fallback writes the statements that compare the values a and b with the comparer.
+func (g *generator) fallback(a string, b string) {
+	g.printf("if !%s.Equal(%s, %s) {\nreturn false\n}\n", g.comparer, a, b)
+}
+
+// named reports whether the type name is declared in the package and can be compared by a helper function.
+func (g *generator) named(name string) bool {
+	spec, ok := g.specs[name]
+	return ok && !spec.Assign.IsValid() && spec.TypeParams == nil && !g.matchers[name]
+}
+
+// need queues the helper function of the type name, if it was not queued before.
+func (g *generator) need(name string) {
+	if !g.queued[name] {
+		g.queued[name] = true
+		g.queue = append(g.queue, name)
+	}
+}
+
+// ignored reports whether the field name is ignored by the options.
+func (g *generator) ignored(name string) bool {
+	if name == "_" || (g.unexported && !ast.IsExported(name)) {
+		return true
+	}
+	for _, ignored := range g.ignore {
+		if name == ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// underlying returns the expression of the underlying type of t, following the types declared in the package.
+func (g *generator) underlying(t ast.Expr) ast.Expr {
+	for i := 0; i < len(g.specs); i++ {
+		if p, ok := t.(*ast.ParenExpr); ok {
+			t = p.X
+		}
+		id, ok := t.(*ast.Ident)
+		if !ok {
+			return t
+		}
+		spec, ok := g.specs[id.Name]
+		if !ok || spec.TypeParams != nil {
+			return t
+		}
+		t = spec.Type
+	}
+	return t
+}
+
+// elem returns the element type of the array or slice type t.
+func (g *generator) elem(t ast.Expr) (ast.Expr, bool) {
+	if a, ok := t.(*ast.ArrayType); ok {
+		return a.Elt, true
+	}
+	return nil, false
+}
+
+// byteElem reports whether t is the predeclared byte or uint8 type.
+func (g *generator) byteElem(t ast.Expr) bool {
+	id, ok := t.(*ast.Ident)
+	return ok && (id.Name == "byte" || id.Name == "uint8") && g.specs[id.Name] == nil
+}
+
+// byteKind reports whether the underlying type of t is byte or uint8, so the comparer compares its arrays and slices byte-wise.
+func (g *generator) byteKind(t ast.Expr) bool {
+	return g.byteElem(g.underlying(t))
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// address returns the expression of the address of the addressable expression x.
+func address(x string) string {
+	if strings.HasPrefix(x, "(*") && strings.HasSuffix(x, ")") {
+		return x[2 : len(x)-1]
+	}
+	return "&" + x
+}
+
+// fieldNames returns the names of the struct field f, that is the name of its type when it is embedded.
+func fieldNames(f *ast.Field) []string {
+	if len(f.Names) == 0 {
+		t := f.Type
+		if star, ok := t.(*ast.StarExpr); ok {
+			t = star.X
+		}
+		switch t := t.(type) {
+		case *ast.Ident:
+			return []string{t.Name}
+		case *ast.SelectorExpr:
+			return []string{t.Sel.Name}
+		case *ast.IndexExpr:
+			return fieldNames(&ast.Field{Type: t.X})
+		case *ast.IndexListExpr:
+			return fieldNames(&ast.Field{Type: t.X})
+		}
+		return nil
+	}
+	names := make([]string, len(f.Names))
+	for i, name := range f.Names {
+		names[i] = name.Name
+	}
+	return names
+}
+
+// test returns the formatted code of the test that checks the functions against the comparer on random values.
+func (g *generator) test() ([]byte, error) {
+	random := "random" + strings.ToUpper(g.types[0][:1]) + g.types[0][1:]
+	names := append([]string(nil), g.types...)
+	sort.Strings(names)
+
+	g.buf.Reset()
+	g.printf("// Code generated by comparergen; DO NOT EDIT.\n\npackage %s\n\n", g.pkg)
+	g.printf("import (\n\"math/rand\"\n\"reflect\"\n\"testing\"\n)\n\n")
+	for _, name := range names {
+		g.printf(strings.ReplaceAll(testTemplate, "{{comparer}}", g.comparer), name, random)
+	}
+	g.printf(randomTemplate, random)
+	return format.Source(g.buf.Bytes())
+}
+
+const testTemplate = `func TestGenerated%[1]s(t *testing.T) {
+	for seed := int64(0); seed < 3000; seed++ {
+		var a, b %[1]s
+		%[2]s(rand.New(rand.NewSource(seed)), reflect.ValueOf(&a).Elem(), 0)
+		switch seed %% 3 {
+		case 0:
+			%[2]s(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem(), 0)
+		case 1:
+			%[2]s(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem(), 0)
+			%[2]sChange(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem())
+		default:
+			%[2]s(rand.New(rand.NewSource(-seed)), reflect.ValueOf(&b).Elem(), 0)
+		}
+
+		if got, want := Equal%[1]s(&a, &b), {{comparer}}.Equal(&a, &b); got != want {
+			t.Fatalf("Equal%[1]s should return %%v for the seed %%d, got %%v", want, seed, got)
+		}
+		comparison, comparable := Compare%[1]s(&a, &b)
+		if want, wantComparable := {{comparer}}.Compare(a, b); comparison != want || comparable != wantComparable {
+			t.Fatalf("Compare%[1]s should return %%v, %%v for the seed %%d, got %%v, %%v", want, wantComparable, seed, comparison, comparable)
+		}
+	}
+}
+
+`
+
+const randomTemplate = `// %[1]s sets v to a random value from a small domain, so the random values are often equal.
+func %[1]s(r *rand.Rand, v reflect.Value, depth int) {
+	if depth > 3 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.Intn(3) - 1))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(r.Intn(3)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(r.Intn(3)) / 2)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(float64(r.Intn(2)), float64(r.Intn(2))))
+	case reflect.String:
+		v.SetString([]string{"", "a", "b"}[r.Intn(3)])
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			%[1]s(r, v.Index(i), depth+1)
+		}
+	case reflect.Slice:
+		if n := r.Intn(4); n < 3 {
+			v.Set(reflect.MakeSlice(v.Type(), n, n))
+			for i := 0; i < n; i++ {
+				%[1]s(r, v.Index(i), depth+1)
+			}
+		}
+	case reflect.Map:
+		if n := r.Intn(4); n < 3 {
+			v.Set(reflect.MakeMap(v.Type()))
+			for i := 0; i < n; i++ {
+				k, e := reflect.New(v.Type().Key()).Elem(), reflect.New(v.Type().Elem()).Elem()
+				%[1]s(r, k, depth+1)
+				%[1]s(r, e, depth+1)
+				v.SetMapIndex(k, e)
+			}
+		}
+	case reflect.Ptr:
+		if r.Intn(3) > 0 {
+			p := reflect.New(v.Type().Elem())
+			%[1]s(r, p.Elem(), depth+1)
+			v.Set(p)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				%[1]s(r, v.Field(i), depth+1)
+			}
+		}
+	case reflect.Interface:
+		if v.NumMethod() == 0 && r.Intn(2) == 0 {
+			v.Set(reflect.ValueOf(r.Intn(2)))
+		}
+	}
+}
+
+// %[1]sChange sets one of the values nested in v to a random value, so the values are often only different in a nested value.
+func %[1]sChange(r *rand.Rand, v reflect.Value) {
+	var values []reflect.Value
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		if !v.CanSet() {
+			return
+		}
+		values = append(values, v)
+		switch v.Kind() {
+		case reflect.Array, reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Ptr:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				walk(v.Field(i))
+			}
+		}
+	}
+	walk(v)
+
+	value := values[r.Intn(len(values))]
+	value.Set(reflect.Zero(value.Type()))
+	%[1]s(r, value, 0)
+}
+`
@@ -0,0 +1,55 @@
+// Package example declares the types used to test the code generated by comparergen.
+package example
+
+import "time"
+
+//go:generate go run github.com/gum-dev-ar/comparer/cmd/comparergen -type Order,Status,Payload -ignore Cache -ignore-unexported -bytes
+
+// Status is the state of an Order.
+type Status int
+
+// Payload is the raw content of an Order.
+type Payload []byte
+
+// Order is a value with most of the kinds of fields that comparergen supports.
+type Order struct {
+	ID       int64
+	Customer *Customer
+	Items    []Item
+	Tags     map[string]bool
+	Status   Status
+	Notes    [2]string
+	Payload  Payload
+	Checksum [4]byte
+	Created  time.Time
+	Extra    interface{}
+	Parent   *Order
+	Cache    map[string]float64
+	Audit
+	total float64
+}
+
+// Customer is the owner of an Order.
+type Customer struct {
+	Name, Email string
+}
+
+// Item is a line of an Order.
+type Item struct {
+	SKU      string
+	Quantity uint
+	Price    Price
+	Labels   Labels
+	Sizes    map[string][]float32
+}
+
+// Price is an amount of money.
+type Price float64
+
+// Labels are the names that classify an Item.
+type Labels []string
+
+// Audit records who changed an Order.
+type Audit struct {
+	Authors []*string
+}
@@ -0,0 +1,219 @@
+// Code generated by comparergen; DO NOT EDIT.
+
+package example
+
+import (
+	"bytes"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+// orderComparer compares the values that the generated code does not compare.
+var orderComparer = comparer.New(comparer.IgnoreFields("Cache"), comparer.IgnoreUnexported(), comparer.BytesComparator())
+
+// EqualOrder reports whether a and b are equal, like the Equal method of orderComparer.
+func EqualOrder(a, b *Order) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalOrder(a, b)
+}
+
+// CompareOrder compares the values of a and b, like the Compare method of orderComparer.
+// The values are not comparable when any of them is nil.
+func CompareOrder(a, b *Order) (int, bool) {
+	return 0, false
+}
+
+// EqualStatus reports whether a and b are equal, like the Equal method of orderComparer.
+func EqualStatus(a, b *Status) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalStatus(a, b)
+}
+
+// CompareStatus compares the values of a and b, like the Compare method of orderComparer.
+// The values are not comparable when any of them is nil.
+func CompareStatus(a, b *Status) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	} else if *a < *b {
+		return -1, true
+	} else if *a > *b {
+		return 1, true
+	}
+	return 0, true
+}
+
+// EqualPayload reports whether a and b are equal, like the Equal method of orderComparer.
+func EqualPayload(a, b *Payload) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalPayload(a, b)
+}
+
+// ComparePayload compares the values of a and b, like the Compare method of orderComparer.
+// The values are not comparable when any of them is nil.
+func ComparePayload(a, b *Payload) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	return bytes.Compare(*a, *b), true
+}
+
+func equalOrder(a, b *Order) bool {
+	if a.ID != b.ID {
+		return false
+	}
+	if a.Customer == nil || b.Customer == nil {
+		if a.Customer != b.Customer {
+			return false
+		}
+	} else {
+		if !equalCustomer(a.Customer, b.Customer) {
+			return false
+		}
+	}
+	if (a.Items == nil) != (b.Items == nil) || len(a.Items) != len(b.Items) {
+		return false
+	}
+	for i0 := range a.Items {
+		if !equalItem(&a.Items[i0], &b.Items[i0]) {
+			return false
+		}
+	}
+	if (a.Tags == nil) != (b.Tags == nil) || len(a.Tags) != len(b.Tags) {
+		return false
+	}
+	for k0, v0 := range a.Tags {
+		w0, ok0 := b.Tags[k0]
+		if !ok0 {
+			return false
+		}
+		if v0 != w0 {
+			return false
+		}
+	}
+	if a.Status != b.Status {
+		return false
+	}
+	for i0 := range a.Notes {
+		if a.Notes[i0] != b.Notes[i0] {
+			return false
+		}
+	}
+	if !equalPayload(&a.Payload, &b.Payload) {
+		return false
+	}
+	if !bytes.Equal(a.Checksum[:], b.Checksum[:]) {
+		return false
+	}
+	if !orderComparer.Equal(a.Created, b.Created) {
+		return false
+	}
+	if !orderComparer.Equal(a.Extra, b.Extra) {
+		return false
+	}
+	if a.Parent == nil || b.Parent == nil {
+		if a.Parent != b.Parent {
+			return false
+		}
+	} else {
+		if !equalOrder(a.Parent, b.Parent) {
+			return false
+		}
+	}
+	if !equalAudit(&a.Audit, &b.Audit) {
+		return false
+	}
+	return true
+}
+
+func equalStatus(a, b *Status) bool {
+	if (*a) != (*b) {
+		return false
+	}
+	return true
+}
+
+func equalPayload(a, b *Payload) bool {
+	if !bytes.Equal((*a), (*b)) {
+		return false
+	}
+	return true
+}
+
+func equalCustomer(a, b *Customer) bool {
+	if a.Name != b.Name {
+		return false
+	}
+	if a.Email != b.Email {
+		return false
+	}
+	return true
+}
+
+func equalItem(a, b *Item) bool {
+	if a.SKU != b.SKU {
+		return false
+	}
+	if a.Quantity != b.Quantity {
+		return false
+	}
+	if a.Price != b.Price {
+		return false
+	}
+	if !equalLabels(&a.Labels, &b.Labels) {
+		return false
+	}
+	if (a.Sizes == nil) != (b.Sizes == nil) || len(a.Sizes) != len(b.Sizes) {
+		return false
+	}
+	for k0, v0 := range a.Sizes {
+		w0, ok0 := b.Sizes[k0]
+		if !ok0 {
+			return false
+		}
+		if (v0 == nil) != (w0 == nil) || len(v0) != len(w0) {
+			return false
+		}
+		for i1 := range v0 {
+			if v0[i1] != w0[i1] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func equalAudit(a, b *Audit) bool {
+	if (a.Authors == nil) != (b.Authors == nil) || len(a.Authors) != len(b.Authors) {
+		return false
+	}
+	for i0 := range a.Authors {
+		if a.Authors[i0] == nil || b.Authors[i0] == nil {
+			if a.Authors[i0] != b.Authors[i0] {
+				return false
+			}
+		} else {
+			if (*a.Authors[i0]) != (*b.Authors[i0]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func equalLabels(a, b *Labels) bool {
+	if ((*a) == nil) != ((*b) == nil) || len((*a)) != len((*b)) {
+		return false
+	}
+	for i0 := range *a {
+		if (*a)[i0] != (*b)[i0] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,170 @@
+// Code generated by comparergen; DO NOT EDIT.
+
+package example
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGeneratedOrder(t *testing.T) {
+	for seed := int64(0); seed < 3000; seed++ {
+		var a, b Order
+		randomOrder(rand.New(rand.NewSource(seed)), reflect.ValueOf(&a).Elem(), 0)
+		switch seed % 3 {
+		case 0:
+			randomOrder(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem(), 0)
+		case 1:
+			randomOrder(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem(), 0)
+			randomOrderChange(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem())
+		default:
+			randomOrder(rand.New(rand.NewSource(-seed)), reflect.ValueOf(&b).Elem(), 0)
+		}
+
+		if got, want := EqualOrder(&a, &b), orderComparer.Equal(&a, &b); got != want {
+			t.Fatalf("EqualOrder should return %v for the seed %d, got %v", want, seed, got)
+		}
+		comparison, comparable := CompareOrder(&a, &b)
+		if want, wantComparable := orderComparer.Compare(a, b); comparison != want || comparable != wantComparable {
+			t.Fatalf("CompareOrder should return %v, %v for the seed %d, got %v, %v", want, wantComparable, seed, comparison, comparable)
+		}
+	}
+}
+
+func TestGeneratedPayload(t *testing.T) {
+	for seed := int64(0); seed < 3000; seed++ {
+		var a, b Payload
+		randomOrder(rand.New(rand.NewSource(seed)), reflect.ValueOf(&a).Elem(), 0)
+		switch seed % 3 {
+		case 0:
+			randomOrder(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem(), 0)
+		case 1:
+			randomOrder(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem(), 0)
+			randomOrderChange(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem())
+		default:
+			randomOrder(rand.New(rand.NewSource(-seed)), reflect.ValueOf(&b).Elem(), 0)
+		}
+
+		if got, want := EqualPayload(&a, &b), orderComparer.Equal(&a, &b); got != want {
+			t.Fatalf("EqualPayload should return %v for the seed %d, got %v", want, seed, got)
+		}
+		comparison, comparable := ComparePayload(&a, &b)
+		if want, wantComparable := orderComparer.Compare(a, b); comparison != want || comparable != wantComparable {
+			t.Fatalf("ComparePayload should return %v, %v for the seed %d, got %v, %v", want, wantComparable, seed, comparison, comparable)
+		}
+	}
+}
+
+func TestGeneratedStatus(t *testing.T) {
+	for seed := int64(0); seed < 3000; seed++ {
+		var a, b Status
+		randomOrder(rand.New(rand.NewSource(seed)), reflect.ValueOf(&a).Elem(), 0)
+		switch seed % 3 {
+		case 0:
+			randomOrder(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem(), 0)
+		case 1:
+			randomOrder(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem(), 0)
+			randomOrderChange(rand.New(rand.NewSource(seed)), reflect.ValueOf(&b).Elem())
+		default:
+			randomOrder(rand.New(rand.NewSource(-seed)), reflect.ValueOf(&b).Elem(), 0)
+		}
+
+		if got, want := EqualStatus(&a, &b), orderComparer.Equal(&a, &b); got != want {
+			t.Fatalf("EqualStatus should return %v for the seed %d, got %v", want, seed, got)
+		}
+		comparison, comparable := CompareStatus(&a, &b)
+		if want, wantComparable := orderComparer.Compare(a, b); comparison != want || comparable != wantComparable {
+			t.Fatalf("CompareStatus should return %v, %v for the seed %d, got %v, %v", want, wantComparable, seed, comparison, comparable)
+		}
+	}
+}
+
+// randomOrder sets v to a random value from a small domain, so the random values are often equal.
+func randomOrder(r *rand.Rand, v reflect.Value, depth int) {
+	if depth > 3 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.Intn(3) - 1))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(r.Intn(3)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(r.Intn(3)) / 2)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(float64(r.Intn(2)), float64(r.Intn(2))))
+	case reflect.String:
+		v.SetString([]string{"", "a", "b"}[r.Intn(3)])
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			randomOrder(r, v.Index(i), depth+1)
+		}
+	case reflect.Slice:
+		if n := r.Intn(4); n < 3 {
+			v.Set(reflect.MakeSlice(v.Type(), n, n))
+			for i := 0; i < n; i++ {
+				randomOrder(r, v.Index(i), depth+1)
+			}
+		}
+	case reflect.Map:
+		if n := r.Intn(4); n < 3 {
+			v.Set(reflect.MakeMap(v.Type()))
+			for i := 0; i < n; i++ {
+				k, e := reflect.New(v.Type().Key()).Elem(), reflect.New(v.Type().Elem()).Elem()
+				randomOrder(r, k, depth+1)
+				randomOrder(r, e, depth+1)
+				v.SetMapIndex(k, e)
+			}
+		}
+	case reflect.Ptr:
+		if r.Intn(3) > 0 {
+			p := reflect.New(v.Type().Elem())
+			randomOrder(r, p.Elem(), depth+1)
+			v.Set(p)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				randomOrder(r, v.Field(i), depth+1)
+			}
+		}
+	case reflect.Interface:
+		if v.NumMethod() == 0 && r.Intn(2) == 0 {
+			v.Set(reflect.ValueOf(r.Intn(2)))
+		}
+	}
+}
+
+// randomOrderChange sets one of the values nested in v to a random value, so the values are often only different in a nested value.
+func randomOrderChange(r *rand.Rand, v reflect.Value) {
+	var values []reflect.Value
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		if !v.CanSet() {
+			return
+		}
+		values = append(values, v)
+		switch v.Kind() {
+		case reflect.Array, reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Ptr:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				walk(v.Field(i))
+			}
+		}
+	}
+	walk(v)
+
+	value := values[r.Intn(len(values))]
+	value.Set(reflect.Zero(value.Type()))
+	randomOrder(r, value, 0)
+}
@@ -0,0 +1,96 @@
+// Comparergen generates typed functions that compare values with the semantics of a comparer.Comparer, without the cost of the reflection.
+//
+// Usage:
+//
+//	comparergen -type Order,Item [-ignore Cache,Token] [-ignore-unexported] [-bytes] [-output order_comparer.go] [-test=false] [dir]
+//
+// For each type Foo, it generates the functions
+//
+//	func EqualFoo(a, b *Foo) bool
+//	func CompareFoo(a, b *Foo) (int, bool)
+//
+// EqualFoo returns the same result as the Equal method of a Comparer for a and b, and CompareFoo the same results as its Compare method
+// for the values of a and b, or that they are not comparable when any of them is nil. The Comparer is created with the configurations
+// equivalent to the flags: comparer.IgnoreFields for -ignore, comparer.IgnoreUnexported for -ignore-unexported and
+// comparer.BytesComparator for -bytes.
+//
+// The values of the types that can not be compared with typed code, like interfaces, functions, generic types, types of other packages
+// and types with a Match method, are compared with a Comparer with the same configuration. The unexported fields are compared by the
+// generated code, while a Comparer panics when they are not strings, so -ignore-unexported is needed to test the types with them.
+//
+// It also generates a test file that checks the generated functions against the Comparer on random values.
+// The default output files are named after the first type, like order_comparer.go and order_comparer_test.go.
+// The helper functions are named after the types they compare, like equalOrder, so all the types of a package must be
+// generated with a single invocation.
+//
+// It is intended to be used in a go:generate directive:
+//
+//	//go:generate go run github.com/gum-dev-ar/comparer/cmd/comparergen -type Order
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	types := flag.String("type", "", "comma-separated list of type names; must be set")
+	ignore := flag.String("ignore", "", "comma-separated list of field names to ignore, like comparer.IgnoreFields")
+	unexported := flag.Bool("ignore-unexported", false, "ignore the unexported fields, like comparer.IgnoreUnexported")
+	bytewise := flag.Bool("bytes", false, "compare the byte slices and arrays byte-wise, like comparer.BytesComparator")
+	output := flag.String("output", "", "output file name; default <type>_comparer.go")
+	test := flag.Bool("test", true, "generate a test file that checks the functions against a Comparer")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: comparergen -type T[,T...] [flags] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *types == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+
+	o := options{
+		types:      split(*types),
+		ignore:     split(*ignore),
+		unexported: *unexported,
+		bytes:      *bytewise,
+	}
+	code, tests, err := generate(dir, o)
+	if err != nil {
+		fail(err)
+	}
+
+	name := *output
+	if name == "" {
+		name = filepath.Join(dir, strings.ToLower(o.types[0])+"_comparer.go")
+	}
+	if err := os.WriteFile(name, code, 0o644); err != nil {
+		fail(err)
+	}
+	if *test {
+		if err := os.WriteFile(strings.TrimSuffix(name, ".go")+"_test.go", tests, 0o644); err != nil {
+			fail(err)
+		}
+	}
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "comparergen: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateExample(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	code, tests, err := generate(dir, options{types: []string{"Order", "Status", "Payload"}, ignore: []string{"Cache"}, unexported: true, bytes: true})
+	if err != nil {
+		t.Fatalf("The generation should succeed, got %v", err)
+	}
+
+	for name, generated := range map[string][]byte{"order_comparer.go": code, "order_comparer_test.go": tests} {
+		committed, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(committed, generated) {
+			t.Errorf("The file %s should be up to date, run go generate", name)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	source := `package sample
+
+type Matched struct{ A int }
+
+func (m *Matched) Match(v interface{}) bool { return true }
+
+type Celsius float64
+
+type Temperature Celsius
+
+type Alias = Celsius
+
+type Box[T any] struct{ V T }
+
+type Sample struct {
+	A Temperature
+	B Alias
+	C *Matched
+	D Box[int]
+	E struct{ X int }
+	F func()
+	G [3]byte
+	hidden int
+	_ int
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "sample.go"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, _, err := generate(dir, options{types: []string{"Sample", "Temperature"}})
+	if err != nil {
+		t.Fatalf("The generation should succeed, got %v", err)
+	}
+	for _, line := range []string{
+		"if a.A != b.A {",
+		"if a.B != b.B {",
+		"if !sampleComparer.Equal(a.C, b.C) {",
+		"if !sampleComparer.Equal(a.D, b.D) {",
+		"if !sampleComparer.Equal(a.E, b.E) {",
+		"if !sampleComparer.Equal(a.F, b.F) {",
+		"for i0 := range a.G {",
+		"if a.hidden != b.hidden {",
+		"} else if *a < *b {",
+	} {
+		if !strings.Contains(string(code), line) {
+			t.Errorf("The code should contain %q, got\n%s", line, code)
+		}
+	}
+	if strings.Contains(string(code), "a._") {
+		t.Errorf("The code should skip the blank fields, got\n%s", code)
+	}
+
+	cases := map[string]options{
+		"Missing": {types: []string{"Missing"}},
+		"Generic": {types: []string{"Box"}},
+	}
+	for name, o := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := generate(dir, o); err == nil {
+				t.Errorf("The generation should fail")
+			}
+		})
+	}
+}
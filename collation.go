@@ -0,0 +1,221 @@
+package comparer
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// A Strength is the level of differences that a locale-aware collation takes into account.
+type Strength int
+
+const (
+	// TertiaryStrength distinguishes the base letters, the diacritics, the case and the width.
+	TertiaryStrength Strength = iota
+	// SecondaryStrength distinguishes the base letters and the diacritics, ignoring the case and the width.
+	SecondaryStrength
+	// PrimaryStrength only distinguishes the base letters.
+	PrimaryStrength
+)
+
+// A scope holds the paths where a configuration applies, including their descendants. An empty scope applies to every path.
+type scope []string
+
+// A stringRule changes how the strings are compared in the paths of its scope.
+type stringRule struct {
+	paths     scope
+	transform func(string) string
+	order     func(string, string) int
+}
+
+// FoldCase returns a new Config that compares the strings ignoring the case, using Unicode case folding.
+// If no paths are provided, it applies to all the strings.
+func FoldCase(paths ...string) Config {
+	return stringTransform(paths, func(s string) string {
+		return cases.Fold().String(s)
+	})
+}
+
+// NormalizeNFC returns a new Config that compares the strings after converting them to the Unicode normalization form C.
+// If no paths are provided, it applies to all the strings.
+func NormalizeNFC(paths ...string) Config {
+	return stringTransform(paths, norm.NFC.String)
+}
+
+// NormalizeNFD returns a new Config that compares the strings after converting them to the Unicode normalization form D.
+// If no paths are provided, it applies to all the strings.
+func NormalizeNFD(paths ...string) Config {
+	return stringTransform(paths, norm.NFD.String)
+}
+
+// NaturalOrder returns a new Config that orders the strings comparing the sequences of digits by their numeric value, so "file2" < "file10".
+// Only identical strings are equal, so "file01" and "file1" are ordered by their bytes.
+// If no paths are provided, it applies to all the strings.
+func NaturalOrder(paths ...string) Config {
+	return stringOrder(paths, naturalCompare)
+}
+
+// Collation returns a new Config that orders the strings following the rules of the language tag.
+// The strings that only differ in the levels ignored by the strength are equal.
+// If no paths are provided, it applies to all the strings.
+func Collation(tag language.Tag, strength Strength, paths ...string) Config {
+	var options []collate.Option
+	switch strength {
+	case SecondaryStrength:
+		options = append(options, collate.IgnoreCase, collate.IgnoreWidth)
+	case PrimaryStrength:
+		options = append(options, collate.Loose)
+	}
+
+	// A collate.Collator is not safe for concurrent use.
+	var mutex sync.Mutex
+	collator := collate.New(tag, options...)
+	return stringOrder(paths, func(a string, b string) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return collator.CompareString(a, b)
+	})
+}
+
+func stringTransform(paths []string, transform func(string) string) Config {
+	return func(comp *Comparer) {
+		comp.strings = append(comp.strings, stringRule{paths: paths, transform: transform})
+	}
+}
+
+func stringOrder(paths []string, order func(string, string) int) Config {
+	return func(comp *Comparer) {
+		comp.strings = append(comp.strings, stringRule{paths: paths, order: order})
+	}
+}
+
+// match reports whether the path is one of the scope paths or one of their descendants.
+func (s scope) match(path string) bool {
+	if len(s) == 0 {
+		return true
+	}
+	return s.matchPath(path) || (strings.ContainsRune(path, '{') && s.matchPath(plain(path)))
+}
+
+func (s scope) matchPath(path string) bool {
+	for _, p := range s {
+		if p == path {
+			return true
+		} else if strings.HasPrefix(path, p) && strings.ContainsRune(".[{", rune(path[len(p)])) {
+			return true
+		}
+	}
+	return false
+}
+
+// plain returns the path without the names of the transformers, like "Name" for "{trim}.Name{trim}".
+// The braces inside the indexes and the keys are kept.
+func plain(path string) string {
+	var sb strings.Builder
+	depth := 0
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case c == '{' && depth == 0:
+			if j := strings.IndexByte(path[i:], '}'); j >= 0 {
+				i += j
+				continue
+			}
+		}
+		sb.WriteByte(path[i])
+	}
+	return strings.TrimPrefix(sb.String(), ".")
+}
+
+// compareStrings applies the transformations in the order they were configured, and then the last configured order.
+func (c *Comparer) compareStrings(path string, a string, b string) int {
+	order := strings.Compare
+	for _, r := range c.strings {
+		if !r.paths.match(path) {
+			continue
+		}
+		if r.transform != nil {
+			a, b = r.transform(a), r.transform(b)
+		}
+		if r.order != nil {
+			order = r.order
+		}
+	}
+	return order(a, b)
+}
+
+func (c *Comparer) bytewise(path string) bool {
+	for _, s := range c.bytes {
+		if s.match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func byteSlice(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice {
+		return v.Bytes()
+	}
+	b := make([]byte, v.Len())
+	for i := range b {
+		b[i] = byte(v.Index(i).Uint())
+	}
+	return b
+}
+
+func naturalCompare(a string, b string) int {
+	x, y := a, b
+	for x != "" && y != "" {
+		if isDigit(x[0]) && isDigit(y[0]) {
+			nx, ny := digits(x), digits(y)
+			tx, ty := strings.TrimLeft(nx, "0"), strings.TrimLeft(ny, "0")
+			if len(tx) < len(ty) {
+				return -1
+			} else if len(tx) > len(ty) {
+				return 1
+			} else if comparison := strings.Compare(tx, ty); comparison != 0 {
+				return comparison
+			}
+			x, y = x[len(nx):], y[len(ny):]
+			continue
+		}
+
+		rx, sx := utf8.DecodeRuneInString(x)
+		ry, sy := utf8.DecodeRuneInString(y)
+		if rx < ry {
+			return -1
+		} else if rx > ry {
+			return 1
+		}
+		x, y = x[sx:], y[sy:]
+	}
+
+	if x == "" && y != "" {
+		return -1
+	} else if x != "" && y == "" {
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func digits(s string) string {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i]
+}
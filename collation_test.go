@@ -0,0 +1,107 @@
+package comparer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+	"golang.org/x/text/language"
+)
+
+type es5 struct {
+	A string
+	B string
+	C [2]byte
+	D []string
+}
+
+type otc struct {
+	configs  []comparer.Config
+	a        interface{}
+	b        interface{}
+	expected int
+}
+
+var ocases = map[string][]otc{
+	"FoldCase": {
+		{[]comparer.Config{comparer.FoldCase()}, "Straße", "STRASSE", 0},
+		{[]comparer.Config{comparer.FoldCase()}, "abc", "ABD", -1},
+		{[]comparer.Config{comparer.FoldCase("B")}, es5{A: "a", B: "b"}, es5{A: "a", B: "B"}, 0},
+		{[]comparer.Config{comparer.FoldCase("B")}, es5{A: "a", B: "b"}, es5{A: "A", B: "b"}, 1},
+		{[]comparer.Config{comparer.FoldCase("D")}, es5{D: []string{"a", "b"}}, es5{D: []string{"A", "B"}}, 0},
+		{[]comparer.Config{comparer.FoldCase("D[1]")}, es5{D: []string{"a", "b"}}, es5{D: []string{"A", "B"}}, 1},
+	},
+	"Normalize": {
+		{[]comparer.Config{comparer.NormalizeNFC()}, "caf\u00e9", "cafe\u0301", 0},
+		{[]comparer.Config{comparer.NormalizeNFD()}, "caf\u00e9", "cafe\u0301", 0},
+		{[]comparer.Config{comparer.NormalizeNFC("A")}, es5{A: "caf\u00e9"}, es5{A: "cafe\u0301"}, 0},
+		{[]comparer.Config{comparer.NormalizeNFC("B")}, es5{A: "caf\u00e9"}, es5{A: "cafe\u0301"}, 1},
+	},
+	"NaturalOrder": {
+		{[]comparer.Config{comparer.NaturalOrder()}, "file2", "file10", -1},
+		{[]comparer.Config{comparer.NaturalOrder()}, "file10", "file10", 0},
+		{[]comparer.Config{comparer.NaturalOrder()}, "file10b", "file10a", 1},
+		{[]comparer.Config{comparer.NaturalOrder()}, "file01", "file1", -1},
+		{[]comparer.Config{comparer.NaturalOrder()}, "file", "file1", -1},
+		{[]comparer.Config{comparer.NaturalOrder(), comparer.FoldCase()}, "File2", "file10", -1},
+	},
+	"Collation": {
+		{[]comparer.Config{comparer.Collation(language.Spanish, comparer.TertiaryStrength)}, "\u00f1u", "ola", -1},
+		{[]comparer.Config{comparer.Collation(language.Spanish, comparer.TertiaryStrength)}, "nu", "\u00f1u", -1},
+		{[]comparer.Config{comparer.Collation(language.Spanish, comparer.TertiaryStrength)}, "resume", "Resume", -1},
+		{[]comparer.Config{comparer.Collation(language.Spanish, comparer.SecondaryStrength)}, "resume", "RESUME", 0},
+		{[]comparer.Config{comparer.Collation(language.Spanish, comparer.SecondaryStrength)}, "resume", "r\u00e9sum\u00e9", -1},
+		{[]comparer.Config{comparer.Collation(language.Spanish, comparer.PrimaryStrength)}, "resume", "R\u00c9SUM\u00c9", 0},
+		{[]comparer.Config{comparer.Collation(language.German, comparer.PrimaryStrength)}, "Stra\u00dfe", "strasse", 0},
+		{[]comparer.Config{comparer.Collation(language.German, comparer.TertiaryStrength)}, "Stra\u00dfe", "strasse", 1},
+		{[]comparer.Config{comparer.Collation(language.German, comparer.PrimaryStrength, "A")}, es5{A: "Stra\u00dfe"}, es5{A: "strasse"}, 0},
+		{[]comparer.Config{comparer.Collation(language.German, comparer.PrimaryStrength, "B")}, es5{A: "Stra\u00dfe"}, es5{A: "strasse"}, -1},
+	},
+	"Bytes": {
+		{[]comparer.Config{comparer.BytesComparator()}, []byte("ab"), []byte("b"), -1},
+		{[]comparer.Config{comparer.BytesComparator()}, [2]byte{1, 2}, [2]byte{1, 3}, -1},
+		{[]comparer.Config{comparer.BytesComparator()}, []byte(nil), []byte{}, 0},
+		{[]comparer.Config{comparer.BytesComparator("C")}, es5{C: [2]byte{1, 2}}, es5{C: [2]byte{1, 2}}, 0},
+	},
+}
+
+func TestCollationCompare(t *testing.T) {
+	for name, cases := range ocases {
+		t.Run(name, func(t *testing.T) {
+			for _, tc := range cases {
+				c := comparer.New(tc.configs...)
+				t.Run(fmt.Sprintf("comparer.Compare(%+v,%+v)", tc.a, tc.b), func(t *testing.T) {
+					if _, ok := tc.a.(es5); ok {
+						t.Skip("The structs are not comparable")
+					}
+					comparison, comparable := c.Compare(tc.a, tc.b)
+					if !comparable {
+						t.Errorf("The values should be comparable")
+					} else if comparison != tc.expected {
+						t.Errorf("The comparison should be %d, got %d", tc.expected, comparison)
+					}
+				})
+				t.Run(fmt.Sprintf("comparer.Equal(%+v,%+v)", tc.a, tc.b), func(t *testing.T) {
+					if c.Equal(tc.a, tc.b) != (tc.expected == 0) {
+						if tc.expected == 0 {
+							t.Errorf("The values should be equal")
+						} else {
+							t.Errorf("The values should not be equal")
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestCollationDefault(t *testing.T) {
+	c := comparer.New()
+
+	if c.Equal("a", "A") {
+		t.Errorf("The values should not be equal")
+	}
+	if _, comparable := c.Compare([]byte("a"), []byte("b")); comparable {
+		t.Errorf("The values should not be comparable")
+	}
+}
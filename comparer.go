@@ -2,9 +2,10 @@
 package comparer
 
 import (
-	"fmt"
+	"bytes"
 	"reflect"
-	"strings"
+	"sync"
+	"unsafe"
 )
 
 // A Config is the function that allows to apply a configuratión to Comparer.
@@ -17,8 +18,29 @@ type Config func(*Comparer)
 type Comparator func(path string, a interface{}, b interface{}) (int, bool)
 
 // A Comparer holds the configurations of the comparison methods.
+//
+// A Comparer is immutable once it is created, so it is safe for concurrent use by multiple goroutines, as long as its Comparators, Transformers,
+// Matchers and other functions are safe too. The With method derives a new Comparer without changing the original one.
 type Comparer struct {
-	c Comparator
+	c          Comparator
+	nodes      []NodeComparator
+	trans      []transformer
+	fields     []fieldFilter
+	entries    []entryFilter
+	subset     bool
+	matchers   map[string]Matcher
+	types      map[reflect.Type]Comparator
+	strings    []stringRule
+	bytes      []scope
+	aligns     []alignment
+	keys       []sliceKey
+	safe       bool
+	maxDepth   int
+	maxNodes   int
+	exhaustive bool
+	workers    int
+	threshold  int
+	cloners    map[reflect.Type]Cloner
 }
 
 // CustomComparator returns a new Config that overrides the Comparator function.
@@ -28,37 +50,103 @@ func CustomComparator(c Comparator) Config {
 	}
 }
 
+// TypeComparator returns a new Config that uses the Comparator c for the values of type t.
+// It is only called when both values have the type t and the custom Comparator does not compare them.
+func TypeComparator(t reflect.Type, c Comparator) Config {
+	return func(comp *Comparer) {
+		if comp.types == nil {
+			comp.types = map[reflect.Type]Comparator{}
+		}
+		comp.types[t] = c
+	}
+}
+
 // New returns a new Comparer with the provided configuration.
 func New(configs ...Config) *Comparer {
 	c := Comparer{}
 	for _, config := range configs {
 		config(&c)
 	}
-	if c.c == nil {
-		c.c = func(p string, l interface{}, r interface{}) (int, bool) {
-			return 0, false
+	return &c
+}
+
+// With returns a new Comparer with the configuration of c and the provided configuration, that is applied after it.
+// The Comparer c is not modified.
+func (c *Comparer) With(configs ...Config) *Comparer {
+	d := *c
+	d.nodes = append([]NodeComparator(nil), c.nodes...)
+	d.trans = append([]transformer(nil), c.trans...)
+	d.fields = append([]fieldFilter(nil), c.fields...)
+	d.entries = append([]entryFilter(nil), c.entries...)
+	d.strings = append([]stringRule(nil), c.strings...)
+	d.bytes = append([]scope(nil), c.bytes...)
+	d.aligns = append([]alignment(nil), c.aligns...)
+	d.keys = append([]sliceKey(nil), c.keys...)
+	if c.matchers != nil {
+		d.matchers = make(map[string]Matcher, len(c.matchers))
+		for k, v := range c.matchers {
+			d.matchers[k] = v
 		}
 	}
-	return &c
+	if c.types != nil {
+		d.types = make(map[reflect.Type]Comparator, len(c.types))
+		for k, v := range c.types {
+			d.types[k] = v
+		}
+	}
+	if c.cloners != nil {
+		d.cloners = make(map[reflect.Type]Cloner, len(c.cloners))
+		for k, v := range c.cloners {
+			d.cloners[k] = v
+		}
+	}
+
+	for _, config := range configs {
+		config(&d)
+	}
+	return &d
 }
 
 // Compare returns an integer comparing two values, and a boolean indicating if the two values are comparable. The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
 func (c *Comparer) Compare(a interface{}, b interface{}) (int, bool) {
-	return c.compare(reflect.ValueOf(a), reflect.ValueOf(b))
+	comparison, comparable, _ := c.compareRoot(c.root(CompareOperation, a, b), nil)
+	return comparison, comparable
 }
 
 // Equal reports whether a and b are equal.
 func (c *Comparer) Equal(a interface{}, b interface{}) bool {
-	return c.equal("", reflect.ValueOf(a), reflect.ValueOf(b))
+	equal, _ := c.equalRoot(c.root(EqualOperation, a, b), nil)
+	return equal
+}
+
+func combine(configs ...Config) Config {
+	return func(comp *Comparer) {
+		for _, config := range configs {
+			config(comp)
+		}
+	}
 }
 
-func (c *Comparer) compare(a reflect.Value, b reflect.Value) (int, bool) {
+func (c *Comparer) compare(n *Node) (comparison int, comparable bool) {
+	if n.limited() {
+		n.check()
+	}
+	if n.guarded() {
+		defer n.guard()
+	} else if c.safe {
+		defer n.uncomparable(&comparison, &comparable)
+	}
+	a, b := n.A, n.B
 	if !a.IsValid() || !b.IsValid() {
-		return 0, false
-	} else if comparison, comparable := c.c("", c.value(a), c.value(b)); comparable {
+		return n.fail(ErrNil)
+	} else if m, ok := c.transform(n); ok {
+		return c.compare(m)
+	} else if comparison, comparable := c.custom(n); comparable {
 		return comparison, comparable
 	} else if a.Type() != b.Type() {
-		return 0, false
+		return n.fail(ErrTypeMismatch)
+	} else if comparison, comparable := c.typed(n); comparable {
+		return comparison, comparable
 	}
 
 	switch a.Kind() {
@@ -87,80 +175,241 @@ func (c *Comparer) compare(a reflect.Value, b reflect.Value) (int, bool) {
 			return 0, true
 		}
 	case reflect.String:
-		return strings.Compare(a.String(), b.String()), true
+		return c.compareStrings(n.Path, a.String(), b.String()), true
+	case reflect.Array, reflect.Slice:
+		if a.Type().Elem().Kind() == reflect.Uint8 && c.bytewise(n.Path) {
+			return bytes.Compare(byteSlice(a), byteSlice(b)), true
+		}
+		return n.fail(ErrUnsupportedKind)
 	default:
-		return 0, false
+		return n.fail(ErrUnsupportedKind)
 	}
 }
 
-func (c *Comparer) equal(path string, a reflect.Value, b reflect.Value) bool {
-	if !a.IsValid() || !b.IsValid() {
-		return a.IsValid() == b.IsValid()
-	} else if comparison, comparable := c.c(path, c.value(a), c.value(b)); comparable {
-		return comparison == 0
+func (c *Comparer) equal(n *Node) bool {
+	if n.limited() {
+		n.check()
+	}
+	if n.guarded() {
+		defer n.guard()
+	}
+	if n.state == nil || n.indirect {
+		return c.equalNode(n)
+	}
+	if n.state.reporter != nil {
+		n.state.reporter.Push(n)
+	}
+	visited := n.state.visited
+	n.state.visited++
+	equal := c.equalNode(n)
+	if n.state.visited == visited+1 {
+		n.state.leaves++
+		if equal {
+			n.state.equalLeaves++
+		}
+	}
+	if n.state.reporter != nil {
+		n.state.reporter.Pop(equal)
+	}
+	return equal
+}
+
+func (c *Comparer) equalNode(n *Node) (equal bool) {
+	if c.safe && !n.guarded() {
+		defer n.unequal(&equal)
+	}
+	n.A, n.B = writable(n.A), writable(n.B)
+	a, b := n.A, n.B
+	if m, ok := c.matcher(n); ok {
+		return m.Match(interfaceOf(b)) || n.report(Modified, "does not match "+m.String())
+	} else if a.IsValid() != b.IsValid() && n.entry {
+		if a.IsValid() {
+			return n.report(Removed, "missing in the second value")
+		}
+		return n.report(Added, "missing in the first value")
+	} else if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid() || n.report(Modified, "one of the values is nil")
+	} else if m, ok := c.transform(n); ok {
+		return c.equal(m)
+	} else if comparison, comparable := c.custom(n); comparable {
+		return comparison == 0 || (!n.delegated && n.report(Modified, "different values for the comparator"))
 	} else if a.Type() != b.Type() {
-		return false
+		return n.report(Modified, "different types")
+	} else if comparison, comparable := c.typed(n); comparable {
+		return comparison == 0 || n.report(Modified, "different values for the type comparator")
 	}
 
 	switch a.Kind() {
 	case reflect.Array:
-		for i := 0; i < a.Len(); i++ {
-			child := path + "[" + fmt.Sprintf("%d", i) + "]"
-			if !c.equal(child, a.Index(i), b.Index(i)) {
-				return false
-			}
+		if a.Type().Elem().Kind() == reflect.Uint8 && c.bytewise(n.Path) {
+			return bytes.Equal(byteSlice(a), byteSlice(b)) || n.report(Modified, "different bytes")
+		}
+		if c.primitive(n) {
+			return equalPrimitives(a, b)
 		}
-		return true
+		return c.each(n, a.Len(), n.index)
 	case reflect.Interface:
-		return c.equal(path, a.Elem(), b.Elem())
+		return c.equal(n.elem(a.Elem(), b.Elem()))
 	case reflect.Map:
+		if n.subset() && a.Len() == 0 {
+			return true
+		}
 		if a.IsNil() != b.IsNil() {
-			return false
+			return n.report(Modified, "one of the values is nil")
 		}
-		if a.Len() != b.Len() {
+		if len(c.entries) == 0 && !n.subset() && !n.exhaustive() && a.Len() != b.Len() {
 			return false
 		}
-		for _, k := range a.MapKeys() {
-			child := path + "[" + fmt.Sprintf("%v", k.Interface()) + "]"
-			if !c.equal(child, a.MapIndex(k), b.MapIndex(k)) {
-				return false
+		keys := n.keys(a)
+		ok := c.each(n, len(keys), func(i int) *Node {
+			if c.ignoreEntry(keys[i], a.MapIndex(keys[i]), b.MapIndex(keys[i])) {
+				return nil
+			}
+			return n.key(keys[i])
+		})
+		if len(c.entries) > 0 || n.exhaustive() {
+			for _, k := range n.keys(b) {
+				if !n.proceed(ok) || n.subset() {
+					break
+				} else if !a.MapIndex(k).IsValid() && !c.ignoreEntry(k, reflect.Value{}, b.MapIndex(k)) {
+					ok = c.equal(n.key(k)) && ok
+				}
 			}
 		}
-		return true
+		return ok
 	case reflect.Ptr:
-		return c.equal(path, a.Elem(), b.Elem())
+		return c.equal(n.elem(a.Elem(), b.Elem()))
 	case reflect.Slice:
+		if a.Type().Elem().Kind() == reflect.Uint8 && c.bytewise(n.Path) {
+			return bytes.Equal(byteSlice(a), byteSlice(b)) || n.report(Modified, "different bytes")
+		}
+		if k, ok := c.sliceKey(n); ok {
+			return c.matchKeys(n, k)
+		}
+		if n.subset() {
+			return c.contains(n)
+		}
 		if a.IsNil() != b.IsNil() {
-			return false
+			return n.report(Modified, "one of the values is nil")
+		}
+		if n.exhaustive() {
+			if ok, aligned := c.align(n); aligned {
+				return ok
+			}
 		}
-		if a.Len() != b.Len() {
+		if c.primitive(n) {
+			return equalPrimitives(a, b)
+		}
+		if !n.exhaustive() && a.Len() != b.Len() {
 			return false
 		}
-		for i := 0; i < a.Len(); i++ {
-			child := path + "[" + fmt.Sprintf("%d", i) + "]"
-			if !c.equal(child, a.Index(i), b.Index(i)) {
-				return false
-			}
+		length := a.Len()
+		if b.Len() > length {
+			length = b.Len()
 		}
-		return true
+		return c.each(n, length, n.index)
 	case reflect.Struct:
-		for i := 0; i < a.Type().NumField(); i++ {
-			child := path
-			if child != "" {
-				child += "."
-			}
-			child += a.Type().Field(i).Name
-			if !c.equal(child, a.Field(i), b.Field(i)) {
-				return false
+		if unexported(a.Type()) {
+			// The values of the unexported fields can only be obtained from addressable structs.
+			n.A, n.B = addressable(a), addressable(b)
+		}
+		ok := true
+		for i := 0; i < a.Type().NumField() && n.proceed(ok); i++ {
+			if c.ignoreField(a.Type().Field(i), n.A.Field(i), n.B.Field(i)) {
+				continue
+			} else if n.subset() && a.Field(i).IsZero() {
+				continue
 			}
+			ok = c.equal(n.field(i)) && ok
 		}
-		return true
+		return ok
+	case reflect.String:
+		return c.compareStrings(n.Path, a.String(), b.String()) == 0 || n.report(Modified, "different strings")
 	default:
-		return reflect.DeepEqual(c.value(a), c.value(b))
+		return reflect.DeepEqual(c.value(a), c.value(b)) || n.report(Modified, "different values")
+	}
+}
+
+// custom calls the Comparator function and then the NodeComparators, starting from the next position of the node.
+func (c *Comparer) custom(n *Node) (int, bool) {
+	if c.c == nil && len(c.nodes) == 0 {
+		return 0, false
+	}
+	a, b := c.value(n.A), c.value(n.B)
+	for n.next <= len(c.nodes) {
+		i := n.next
+		n.next++
+
+		var comparison int
+		var comparable bool
+		if i == 0 {
+			if c.c == nil {
+				continue
+			}
+			comparison, comparable = c.c(n.Path, a, b)
+		} else {
+			comparison, comparable = c.nodes[i-1](n, a, b)
+		}
+		if comparable {
+			return comparison, comparable
+		}
+	}
+	return 0, false
+}
+
+// result compares the values of the node with the method of its operation.
+func (c *Comparer) result(n *Node) (int, bool) {
+	if n.Operation == CompareOperation {
+		return c.compare(n)
+	} else if c.equal(n) {
+		return 0, true
+	} else {
+		return 1, true
 	}
 }
 
+func (c *Comparer) typed(n *Node) (int, bool) {
+	if t, ok := c.types[n.A.Type()]; ok {
+		return t(n.Path, c.value(n.A), c.value(n.B))
+	}
+	return 0, false
+}
+
+// value returns the value v as an interface, even when it was obtained from an unexported field.
 func (c *Comparer) value(v reflect.Value) interface{} {
-	//BUG(kupuka): Panic if try to compare nested structs with unexported fields.
-	return v.Interface()
+	return writable(v).Interface()
+}
+
+// writable returns the addressable value v without the restrictions of the unexported fields, so it can be read and set.
+func writable(v reflect.Value) reflect.Value {
+	if v.CanSet() || !v.CanAddr() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// addressable returns the value v, or an addressable copy of it when it is not addressable.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	return copyOf(v)
+}
+
+// unexportedTypes caches the result of unexported for each struct type.
+var unexportedTypes sync.Map
+
+// unexported reports whether the struct type t has unexported fields.
+func unexported(t reflect.Type) bool {
+	if result, ok := unexportedTypes.Load(t); ok {
+		return result.(bool)
+	}
+	result := false
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			result = true
+		}
+	}
+	unexportedTypes.Store(t, result)
+	return result
 }
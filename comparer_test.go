@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/gum-dev-ar/comparer"
@@ -722,3 +723,50 @@ func TestDefaultEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestWith(t *testing.T) {
+	base := comparer.New(comparer.IgnoreFields("A"))
+	derived := base.With(comparer.IgnoreFields("B"), comparer.PathMatcher("C", comparer.Any()))
+
+	a, b := es1{1, "a"}, es1{2, "b"}
+	if base.Equal(a, b) {
+		t.Errorf("The base comparer should not be changed")
+	}
+	if !derived.Equal(a, b) {
+		t.Errorf("The derived comparer should have both configurations")
+	}
+
+	// The derived comparers must not share the slices of the base comparer.
+	first := base.With(comparer.IgnoreFields("B"))
+	second := base.With(comparer.IgnoreFields("C"))
+	if !first.Equal(a, b) || second.Equal(a, b) {
+		t.Errorf("The derived comparers should be independent")
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	base := comparer.New(comparer.StdlibComparators(), comparer.FoldCase(), comparer.SliceKeyField("A"))
+	a := []es1{{1, "a"}, {2, "b"}, {3, "c"}}
+	b := []es1{{3, "C"}, {1, "A"}, {2, "x"}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := base
+			if i%2 == 0 {
+				c = base.With(comparer.IgnoreFields("B"), comparer.Exhaustive())
+			}
+			for j := 0; j < 100; j++ {
+				c.Equal(a, b)
+				c.Compare(j, i)
+				if diffs := base.Diff(a, b); len(diffs) != 1 {
+					t.Errorf("The differences should be stable, got %+v", diffs)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
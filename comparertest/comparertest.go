@@ -0,0 +1,156 @@
+// Package comparertest provides test helpers that check values with a comparer.Comparer and report the differences with their paths.
+package comparertest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+// Equal checks that got is equal to want, and reports the differences as an error if they are not.
+// The error lists the paths of the differences, followed by a tree rendered by a comparer.TextReporter.
+// If c is nil, a default comparer is used. It returns whether the check succeeded.
+func Equal(t testing.TB, c *comparer.Comparer, want interface{}, got interface{}) bool {
+	t.Helper()
+	return equal(t, t.Errorf, c, want, got)
+}
+
+// RequireEqual is like Equal, but it stops the test if the check fails.
+func RequireEqual(t testing.TB, c *comparer.Comparer, want interface{}, got interface{}) {
+	t.Helper()
+	equal(t, t.Fatalf, c, want, got)
+}
+
+// NotEqual checks that got is not equal to want, and reports an error if they are equal.
+// If c is nil, a default comparer is used. It returns whether the check succeeded.
+func NotEqual(t testing.TB, c *comparer.Comparer, want interface{}, got interface{}) bool {
+	t.Helper()
+	return notEqual(t, t.Errorf, c, want, got)
+}
+
+// RequireNotEqual is like NotEqual, but it stops the test if the check fails.
+func RequireNotEqual(t testing.TB, c *comparer.Comparer, want interface{}, got interface{}) {
+	t.Helper()
+	notEqual(t, t.Fatalf, c, want, got)
+}
+
+// Less checks that a is less than b, and reports an error if it is not or if the values are not comparable.
+// If c is nil, a default comparer is used. It returns whether the check succeeded.
+func Less(t testing.TB, c *comparer.Comparer, a interface{}, b interface{}) bool {
+	t.Helper()
+	return less(t, t.Errorf, c, a, b)
+}
+
+// RequireLess is like Less, but it stops the test if the check fails.
+func RequireLess(t testing.TB, c *comparer.Comparer, a interface{}, b interface{}) {
+	t.Helper()
+	less(t, t.Fatalf, c, a, b)
+}
+
+// Sorted checks that the elements of the slice or array s are sorted in ascending order, and reports the first pair that is not.
+// If c is nil, a default comparer is used. It returns whether the check succeeded.
+func Sorted(t testing.TB, c *comparer.Comparer, s interface{}) bool {
+	t.Helper()
+	return sorted(t, t.Errorf, c, s)
+}
+
+// RequireSorted is like Sorted, but it stops the test if the check fails.
+func RequireSorted(t testing.TB, c *comparer.Comparer, s interface{}) {
+	t.Helper()
+	sorted(t, t.Fatalf, c, s)
+}
+
+type failure func(format string, args ...interface{})
+
+func equal(t testing.TB, fail failure, c *comparer.Comparer, want interface{}, got interface{}) bool {
+	t.Helper()
+	c = comparerOf(c)
+	if c.Equal(want, got) {
+		return true
+	}
+	r := &comparer.TextReporter{}
+	c.Report(want, got, r)
+	fail("The values should be equal:\n%s\n%s", Format(c.Diff(want, got)), r)
+	return false
+}
+
+func notEqual(t testing.TB, fail failure, c *comparer.Comparer, want interface{}, got interface{}) bool {
+	t.Helper()
+	if !comparerOf(c).Equal(want, got) {
+		return true
+	}
+	fail("The values should not be equal: %#v", got)
+	return false
+}
+
+func less(t testing.TB, fail failure, c *comparer.Comparer, a interface{}, b interface{}) bool {
+	t.Helper()
+	comparison, comparable := comparerOf(c).Compare(a, b)
+	if !comparable {
+		fail("The values %#v and %#v should be comparable", a, b)
+		return false
+	} else if comparison >= 0 {
+		fail("The value %#v should be less than the value %#v", a, b)
+		return false
+	}
+	return true
+}
+
+func sorted(t testing.TB, fail failure, c *comparer.Comparer, s interface{}) bool {
+	t.Helper()
+	c = comparerOf(c)
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		fail("The value %#v should be a slice or an array", s)
+		return false
+	}
+	for i := 1; i < v.Len(); i++ {
+		a, b := v.Index(i-1).Interface(), v.Index(i).Interface()
+		comparison, comparable := c.Compare(a, b)
+		if !comparable {
+			fail("The elements [%d] %#v and [%d] %#v should be comparable", i-1, a, i, b)
+			return false
+		} else if comparison > 0 {
+			fail("The elements should be sorted, but [%d] %#v is greater than [%d] %#v", i-1, a, i, b)
+			return false
+		}
+	}
+	return true
+}
+
+// Format returns the differences as readable text, with one line for each difference.
+func Format(diffs []comparer.Difference) string {
+	var sb strings.Builder
+	for _, d := range diffs {
+		path := d.Path
+		if path == "" {
+			path = "(root)"
+		}
+		switch d.Change {
+		case comparer.Added:
+			fmt.Fprintf(&sb, "\t%s: unexpected %s\n", path, show(d.B))
+		case comparer.Removed:
+			fmt.Fprintf(&sb, "\t%s: missing %s\n", path, show(d.A))
+		default:
+			fmt.Fprintf(&sb, "\t%s: want %s, got %s\n", path, show(d.A), show(d.B))
+		}
+	}
+	return sb.String()
+}
+
+func show(v interface{}) string {
+	if m, ok := v.(comparer.Matcher); ok {
+		return m.String()
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+func comparerOf(c *comparer.Comparer) *comparer.Comparer {
+	if c == nil {
+		return comparer.New()
+	}
+	return c
+}
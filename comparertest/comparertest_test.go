@@ -0,0 +1,125 @@
+package comparertest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+	"github.com/gum-dev-ar/comparer/comparertest"
+)
+
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	r.fatal = true
+}
+
+type es1 struct {
+	A int
+	B string
+	C []string
+}
+
+func TestEqual(t *testing.T) {
+	r := &recorder{}
+	if !comparertest.Equal(r, nil, es1{1, "a", []string{"x"}}, es1{1, "a", []string{"x"}}) || len(r.errors) != 0 {
+		t.Errorf("The check should succeed")
+	}
+
+	if comparertest.Equal(r, nil, es1{1, "a", []string{"x"}}, es1{2, "a", []string{"x", "y"}}) || len(r.errors) != 1 || r.fatal {
+		t.Fatalf("The check should fail with an error")
+	}
+	for _, line := range []string{"A: want 1, got 2", `C[1]: unexpected "y"`} {
+		if !strings.Contains(r.errors[0], line) {
+			t.Errorf("The error should contain %q, got %q", line, r.errors[0])
+		}
+	}
+
+	r = &recorder{}
+	comparertest.RequireEqual(r, nil, 1, 2)
+	if !r.fatal {
+		t.Errorf("The check should stop the test")
+	}
+}
+
+func TestEqualComparer(t *testing.T) {
+	r := &recorder{}
+	c := comparer.New(comparer.FoldCase())
+	if !comparertest.Equal(r, c, es1{1, "a", nil}, es1{1, "A", nil}) {
+		t.Errorf("The check should use the comparer")
+	}
+
+	c = comparer.New(comparer.Subset())
+	comparertest.Equal(r, c, map[string]interface{}{"id": comparer.Regex("^usr_"), "name": "a"}, map[string]interface{}{"id": "grp_1"})
+	for _, line := range []string{`[id]: want Regex("^usr_"), got "grp_1"`, `[name]: missing "a"`} {
+		if len(r.errors) != 1 || !strings.Contains(r.errors[0], line) {
+			t.Errorf("The error should contain %q, got %q", line, r.errors)
+		}
+	}
+}
+
+func TestNotEqual(t *testing.T) {
+	r := &recorder{}
+	if !comparertest.NotEqual(r, nil, 1, 2) || len(r.errors) != 0 {
+		t.Errorf("The check should succeed")
+	}
+	if comparertest.NotEqual(r, nil, 1, 1) || len(r.errors) != 1 {
+		t.Errorf("The check should fail with an error")
+	}
+
+	r = &recorder{}
+	comparertest.RequireNotEqual(r, nil, 1, 1)
+	if !r.fatal {
+		t.Errorf("The check should stop the test")
+	}
+}
+
+func TestLess(t *testing.T) {
+	r := &recorder{}
+	if !comparertest.Less(r, nil, 1, 2) || len(r.errors) != 0 {
+		t.Errorf("The check should succeed")
+	}
+	if comparertest.Less(r, nil, 2, 2) || len(r.errors) != 1 {
+		t.Errorf("The check should fail with an error")
+	}
+	if comparertest.Less(r, nil, 1, "2") || len(r.errors) != 2 || !strings.Contains(r.errors[1], "comparable") {
+		t.Errorf("The check should fail because the values are not comparable")
+	}
+
+	r = &recorder{}
+	comparertest.RequireLess(r, nil, 2, 1)
+	if !r.fatal {
+		t.Errorf("The check should stop the test")
+	}
+}
+
+func TestSorted(t *testing.T) {
+	r := &recorder{}
+	if !comparertest.Sorted(r, nil, []int{1, 2, 2, 3}) || len(r.errors) != 0 {
+		t.Errorf("The check should succeed")
+	}
+	if !comparertest.Sorted(r, comparer.New(comparer.NaturalOrder()), [3]string{"f1", "f2", "f10"}) || len(r.errors) != 0 {
+		t.Errorf("The check should use the comparer")
+	}
+	if comparertest.Sorted(r, nil, []int{1, 3, 2}) || len(r.errors) != 1 || !strings.Contains(r.errors[0], "[1] 3") {
+		t.Errorf("The check should fail with the unsorted pair, got %q", r.errors)
+	}
+
+	r = &recorder{}
+	comparertest.RequireSorted(r, nil, []int{2, 1})
+	if !r.fatal {
+		t.Errorf("The check should stop the test")
+	}
+}
@@ -0,0 +1,482 @@
+package comparertest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+const (
+	// maxDepth is the depth of the nested values generated by Random, after which the values are zero.
+	maxDepth = 4
+	// maxLen is the maximum number of elements of the slices and maps generated by Random.
+	maxLen = 4
+	// maxShrinks is the maximum number of times that a failing case is replaced by a smaller one.
+	maxShrinks = 1000
+)
+
+// A Source provides the random numbers used to generate the values. It is implemented by *rand.Rand.
+type Source interface {
+	// Intn returns a number in the interval [0, n).
+	Intn(n int) int
+}
+
+// Random returns a random value of the type t, built from the numbers of the source s.
+// It generates nested structs, maps, slices, arrays and pointers, with nil values, up to a fixed depth.
+// The numbers include the extreme values of their types, but not NaN, that is not equal to itself.
+// The unexported fields, the functions and the channels are left zero, and the interfaces without methods hold
+// booleans, numbers or strings. The value is addressable.
+func Random(s Source, t reflect.Type) reflect.Value {
+	v := reflect.New(t).Elem()
+	random(s, v, 0)
+	return v
+}
+
+// Check checks the invariants of the comparer c on n pairs of random values of the type typ, and reports the first pair that breaks any of them.
+// The pairs are equal, different in a single nested value or unrelated. The reported pair is shrunk to the smallest one that still breaks the invariant.
+// If c is nil, a default comparer is used. It returns whether the check succeeded.
+//
+// The invariants are that a value is equal to itself and to its deep copy, that Equal is symmetric and that Compare is antisymmetric.
+// A panic of the comparer also breaks the invariants.
+func Check(t testing.TB, c *comparer.Comparer, typ reflect.Type, n int) bool {
+	t.Helper()
+	return CheckHash(t, c, nil, typ, n)
+}
+
+// A HashFunc returns the hash of the value v. The values that are equal for a comparer must have the same hash.
+type HashFunc func(v interface{}) uint64
+
+// CheckHash is like Check, but it also checks that the values that are equal for the comparer c have the same hash for the function hash,
+// like the hash of the keys of a map indexed by the comparer. If hash is nil, it is the same as Check.
+func CheckHash(t testing.TB, c *comparer.Comparer, hash HashFunc, typ reflect.Type, n int) bool {
+	t.Helper()
+	c = comparerOf(c)
+	for seed := int64(0); seed < int64(n); seed++ {
+		if !verify(t, t.Errorf, c, hash, typ, rand.New(rand.NewSource(seed))) {
+			return false
+		}
+	}
+	return true
+}
+
+// Fuzz checks the invariants of the comparer c, like Check, on the pairs of values generated from the inputs of the fuzz test f.
+// It adds a few random inputs to the seed corpus. If c is nil, a default comparer is used.
+//
+//	func FuzzOrder(f *testing.F) {
+//		comparertest.Fuzz(f, c, reflect.TypeOf(Order{}))
+//	}
+func Fuzz(f *testing.F, c *comparer.Comparer, typ reflect.Type) {
+	f.Helper()
+	FuzzHash(f, c, nil, typ)
+}
+
+// FuzzHash is like Fuzz, but it also checks the hashes of the equal values, like CheckHash. If hash is nil, it is the same as Fuzz.
+func FuzzHash(f *testing.F, c *comparer.Comparer, hash HashFunc, typ reflect.Type) {
+	f.Helper()
+	c = comparerOf(c)
+	for seed := int64(0); seed < 8; seed++ {
+		data := make([]byte, 64)
+		rand.New(rand.NewSource(seed)).Read(data)
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		t.Helper()
+		verify(t, t.Fatalf, c, hash, typ, &bytesSource{data: data})
+	})
+}
+
+// bytesSource is a Source that reads the numbers from the input of a fuzz test, and returns 0 when the input is consumed.
+type bytesSource struct {
+	data []byte
+}
+
+func (s *bytesSource) Intn(n int) int {
+	size := 1
+	if n > math.MaxUint8 {
+		size = 4
+	}
+	var v uint32
+	for i := 0; i < size && len(s.data) > 0; i++ {
+		v = v<<8 | uint32(s.data[0])
+		s.data = s.data[1:]
+	}
+	return int(v % uint32(n))
+}
+
+// recording is a Source that records the numbers of another source, so the values can be generated again from them.
+type recording struct {
+	source  Source
+	choices []int
+}
+
+func (s *recording) Intn(n int) int {
+	v := s.source.Intn(n)
+	s.choices = append(s.choices, v)
+	return v
+}
+
+// replay is a Source that returns recorded numbers, and 0 when they are consumed.
+type replay struct {
+	choices []int
+}
+
+func (s *replay) Intn(n int) int {
+	if len(s.choices) == 0 {
+		return 0
+	}
+	v := s.choices[0] % n
+	s.choices = s.choices[1:]
+	return v
+}
+
+func verify(t testing.TB, fail failure, c *comparer.Comparer, hash HashFunc, typ reflect.Type, s Source) bool {
+	t.Helper()
+	r := &recording{source: s}
+	x, y := pair(r, typ)
+	err := invariants(c, hash, x, y)
+	if err == nil {
+		return true
+	}
+	x, y, err = shrink(c, hash, typ, r.choices, err)
+	fail("The comparer should satisfy its invariants, but %v for the values:\n\tx: %#v\n\ty: %#v", err, x.Interface(), y.Interface())
+	return false
+}
+
+// invariants returns an error describing the first invariant of the comparer c and the hash function, if it is not nil, that the values x and y break.
+func invariants(c *comparer.Comparer, hash HashFunc, x reflect.Value, y reflect.Value) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("it panics with %v", r)
+		}
+	}()
+
+	a, b := x.Interface(), y.Interface()
+	if !c.Equal(a, a) {
+		return fmt.Errorf("x is not equal to itself")
+	} else if !c.Equal(a, deepCopy(x).Interface()) {
+		return fmt.Errorf("x is not equal to its deep copy")
+	}
+
+	equal := c.Equal(a, b)
+	if equal != c.Equal(b, a) {
+		return fmt.Errorf("Equal(x, y) is %v, but Equal(y, x) is not", equal)
+	}
+
+	xy, xyComparable := c.Compare(a, b)
+	yx, yxComparable := c.Compare(b, a)
+	if xyComparable != yxComparable {
+		return fmt.Errorf("Compare(x, y) is comparable %v, but Compare(y, x) is not", xyComparable)
+	} else if xyComparable && sign(xy) != -sign(yx) {
+		return fmt.Errorf("Compare(x, y) is %d, but Compare(y, x) is %d", xy, yx)
+	}
+
+	if hash != nil && equal && hash(a) != hash(b) {
+		return fmt.Errorf("x and y are equal, but their hashes are different")
+	}
+	return nil
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	} else if n > 0 {
+		return 1
+	}
+	return 0
+}
+
+// pair returns two random values of the type t that are equal, different in a single nested value or unrelated.
+func pair(s Source, t reflect.Type) (reflect.Value, reflect.Value) {
+	x := Random(s, t)
+	switch s.Intn(3) {
+	case 0:
+		return x, deepCopy(x)
+	case 1:
+		y := deepCopy(x)
+		change(s, y)
+		return x, y
+	default:
+		return x, Random(s, t)
+	}
+}
+
+// change sets one of the values nested in the addressable value v to a random value.
+func change(s Source, v reflect.Value) {
+	var values []reflect.Value
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		if !v.CanSet() {
+			return
+		}
+		values = append(values, v)
+		switch v.Kind() {
+		case reflect.Array, reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Ptr:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				walk(v.Field(i))
+			}
+		}
+	}
+	walk(v)
+
+	value := values[s.Intn(len(values))]
+	value.Set(reflect.Zero(value.Type()))
+	random(s, value, 0)
+}
+
+// random sets the addressable value v to a random value. The number 0 of the source chooses the zero values, so the shrunk choices generate smaller values.
+func random(s Source, v reflect.Value, depth int) {
+	if depth > maxDepth {
+		return
+	}
+
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(s.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		max := int64(1)<<(t.Bits()-1) - 1
+		v.SetInt([]int64{0, 1, -1, int64(s.Intn(201) - 100), max, -max - 1}[s.Intn(6)])
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		max := uint64(1)<<(t.Bits()-1)<<1 - 1
+		v.SetUint([]uint64{0, 1, uint64(s.Intn(201)), max}[s.Intn(4)])
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(randomFloat(s))
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(randomFloat(s), randomFloat(s)))
+	case reflect.String:
+		v.SetString(randomString(s))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			random(s, v.Index(i), depth+1)
+		}
+	case reflect.Slice:
+		if n := s.Intn(maxLen+2) - 1; n >= 0 {
+			v.Set(reflect.MakeSlice(t, n, n))
+			for i := 0; i < n; i++ {
+				random(s, v.Index(i), depth+1)
+			}
+		}
+	case reflect.Map:
+		if n := s.Intn(maxLen+2) - 1; n >= 0 {
+			v.Set(reflect.MakeMap(t))
+			for i := 0; i < n; i++ {
+				k, e := reflect.New(t.Key()).Elem(), reflect.New(t.Elem()).Elem()
+				random(s, k, depth+1)
+				random(s, e, depth+1)
+				v.SetMapIndex(k, e)
+			}
+		}
+	case reflect.Ptr:
+		if s.Intn(4) > 0 {
+			p := reflect.New(t.Elem())
+			random(s, p.Elem(), depth+1)
+			v.Set(p)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				random(s, v.Field(i), depth+1)
+			}
+		}
+	case reflect.Interface:
+		if t.NumMethod() > 0 {
+			return
+		}
+		switch s.Intn(5) {
+		case 1:
+			v.Set(reflect.ValueOf(s.Intn(2) == 1))
+		case 2:
+			v.Set(reflect.ValueOf(s.Intn(201) - 100))
+		case 3:
+			v.Set(reflect.ValueOf(randomFloat(s)))
+		case 4:
+			v.Set(reflect.ValueOf(randomString(s)))
+		}
+	}
+}
+
+func randomFloat(s Source) float64 {
+	return []float64{0, 1, -1.5, float64(s.Intn(2001)-1000) / 10, math.Inf(1), math.Inf(-1), math.SmallestNonzeroFloat32}[s.Intn(7)]
+}
+
+func randomString(s Source) string {
+	runes := []rune("aAbB0 é")
+	r := make([]rune, s.Intn(5))
+	for i := range r {
+		r[i] = runes[s.Intn(len(runes))]
+	}
+	return string(r)
+}
+
+// deepCopy returns an addressable copy of v, made by comparer.Clone.
+func deepCopy(v reflect.Value) reflect.Value {
+	w := reflect.New(v.Type()).Elem()
+	if c := comparer.Clone(v.Interface()); c != nil {
+		w.Set(reflect.ValueOf(c))
+	}
+	return w
+}
+
+// shrink returns the smallest pair of values of the type typ that breaks an invariant of the comparer c and the hash function, starting from the pair
+// generated from the choices, that breaks it with the error err. It first shrinks the choices, so the values shrink together, and then each value.
+func shrink(c *comparer.Comparer, hash HashFunc, typ reflect.Type, choices []int, err error) (reflect.Value, reflect.Value, error) {
+	x, y := pair(&replay{choices: choices}, typ)
+	for i := 0; i < maxShrinks; i++ {
+		shrunk := false
+		for _, candidate := range fewer(choices) {
+			cx, cy := pair(&replay{choices: candidate}, typ)
+			if e := invariants(c, hash, cx, cy); e != nil {
+				choices, x, y, err, shrunk = candidate, cx, cy, e, true
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return shrinkValues(c, hash, x, y, err)
+}
+
+// fewer returns the choices that are smaller than choices, without some of them or with a smaller one.
+func fewer(choices []int) [][]int {
+	var candidates [][]int
+	for size := 8; size > 0; size /= 2 {
+		for i := 0; i+size <= len(choices); i++ {
+			candidates = append(candidates, append(append([]int(nil), choices[:i]...), choices[i+size:]...))
+		}
+	}
+	for i, choice := range choices {
+		seen := map[int]bool{choice: true}
+		for _, smaller := range []int{0, choice / 2, choice - 1} {
+			if !seen[smaller] && smaller >= 0 {
+				seen[smaller] = true
+				candidate := append([]int(nil), choices...)
+				candidate[i] = smaller
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
+
+// shrinkValues replaces the values x and y by smaller ones while they break an invariant of the comparer c and the hash function,
+// and returns the smallest ones with their error.
+func shrinkValues(c *comparer.Comparer, hash HashFunc, x reflect.Value, y reflect.Value, err error) (reflect.Value, reflect.Value, error) {
+	for i := 0; i < maxShrinks; i++ {
+		shrunk := false
+		for _, candidate := range smaller(x) {
+			if e := invariants(c, hash, candidate, y); e != nil {
+				x, err, shrunk = candidate, e, true
+				break
+			}
+		}
+		if !shrunk {
+			for _, candidate := range smaller(y) {
+				if e := invariants(c, hash, x, candidate); e != nil {
+					y, err, shrunk = candidate, e, true
+					break
+				}
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return x, y, err
+}
+
+// smaller returns the values that are smaller than v, starting with the zero value: the shorter strings, slices and maps,
+// the numbers closer to zero, and the values with a smaller nested value.
+func smaller(v reflect.Value) []reflect.Value {
+	if v.IsZero() {
+		return nil
+	}
+	values := []reflect.Value{reflect.New(v.Type()).Elem()}
+	with := func(f func(w reflect.Value)) {
+		w := deepCopy(v)
+		f(w)
+		values = append(values, w)
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int()/2 != 0 {
+			with(func(w reflect.Value) { w.SetInt(v.Int() / 2) })
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint()/2 != 0 {
+			with(func(w reflect.Value) { w.SetUint(v.Uint() / 2) })
+		}
+	case reflect.Float32, reflect.Float64:
+		if f := math.Trunc(v.Float()); f != v.Float() && !math.IsInf(v.Float(), 0) {
+			with(func(w reflect.Value) { w.SetFloat(f) })
+		}
+	case reflect.String:
+		if r := []rune(v.String()); len(r) > 1 {
+			with(func(w reflect.Value) { w.SetString(string(r[:len(r)/2])) })
+			with(func(w reflect.Value) { w.SetString(string(r[1:])) })
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			i := i
+			with(func(w reflect.Value) { w.Set(reflect.AppendSlice(w.Slice(0, i), w.Slice(i+1, w.Len()))) })
+		}
+		for i := 0; i < v.Len(); i++ {
+			for _, e := range smaller(v.Index(i)) {
+				e, i := e, i
+				with(func(w reflect.Value) { w.Index(i).Set(e) })
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			for _, e := range smaller(v.Index(i)) {
+				e, i := e, i
+				with(func(w reflect.Value) { w.Index(i).Set(e) })
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			k := k
+			with(func(w reflect.Value) { w.SetMapIndex(k, reflect.Value{}) })
+		}
+		for _, k := range v.MapKeys() {
+			for _, e := range smaller(v.MapIndex(k)) {
+				k, e := k, e
+				with(func(w reflect.Value) { w.SetMapIndex(k, e) })
+			}
+		}
+	case reflect.Ptr:
+		for _, e := range smaller(v.Elem()) {
+			e := e
+			with(func(w reflect.Value) { w.Elem().Set(e) })
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Field(i).CanInterface() {
+				continue
+			}
+			for _, e := range smaller(v.Field(i)) {
+				e, i := e, i
+				with(func(w reflect.Value) { w.Field(i).Set(e) })
+			}
+		}
+	case reflect.Interface:
+		for _, e := range smaller(v.Elem()) {
+			e := e
+			with(func(w reflect.Value) { w.Set(e) })
+		}
+	}
+	return values
+}
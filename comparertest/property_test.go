@@ -0,0 +1,104 @@
+package comparertest_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+	"github.com/gum-dev-ar/comparer/comparertest"
+)
+
+type es2 struct {
+	A int
+	B *es2
+	C []string
+	D map[string]float64
+	E interface{}
+	F [2]uint8
+	g int
+}
+
+func TestRandom(t *testing.T) {
+	typ := reflect.TypeOf(es2{})
+	a := comparertest.Random(rand.New(rand.NewSource(1)), typ).Interface()
+	b := comparertest.Random(rand.New(rand.NewSource(1)), typ).Interface()
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("The values of the same seed should be equal, got %#v and %#v", a, b)
+	}
+
+	kinds := map[string]bool{}
+	for seed := int64(0); seed < 100; seed++ {
+		v := comparertest.Random(rand.New(rand.NewSource(seed)), typ).Interface().(es2)
+		kinds["nil pointer"] = kinds["nil pointer"] || v.B == nil
+		kinds["pointer"] = kinds["pointer"] || v.B != nil
+		kinds["nil slice"] = kinds["nil slice"] || v.C == nil
+		kinds["slice"] = kinds["slice"] || len(v.C) > 0
+		kinds["nil map"] = kinds["nil map"] || v.D == nil
+		kinds["map"] = kinds["map"] || len(v.D) > 0
+		kinds["interface"] = kinds["interface"] || v.E != nil
+	}
+	if len(kinds) != 7 {
+		t.Errorf("The values should have all the kinds, got %v", kinds)
+	}
+	for kind, found := range kinds {
+		if !found {
+			t.Errorf("The values should have a %s", kind)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	r := &recorder{}
+	if !comparertest.Check(r, comparer.New(comparer.IgnoreUnexported()), reflect.TypeOf(es2{}), 500) || len(r.errors) != 0 {
+		t.Errorf("The check should succeed, got %q", r.errors)
+	}
+}
+
+func TestCheckShrink(t *testing.T) {
+	lessOrEqual := comparer.TypeComparator(reflect.TypeOf(0), func(_ string, a, b interface{}) (int, bool) {
+		if a.(int) <= b.(int) {
+			return 0, true
+		}
+		return 1, true
+	})
+
+	r := &recorder{}
+	if comparertest.Check(r, comparer.New(lessOrEqual, comparer.IgnoreUnexported()), reflect.TypeOf(es2{}), 500) || len(r.errors) != 1 {
+		t.Fatalf("The check should fail with an error, got %q", r.errors)
+	}
+	zero := "comparertest_test.es2{A:0, B:(*comparertest_test.es2)(nil), C:[]string(nil), D:map[string]float64(nil), E:interface {}(nil), F:[2]uint8{0x0, 0x0}, g:0}"
+	for _, s := range []string{"Equal(x, y) is", "x: " + zero, "y: comparertest_test.es2{A:"} {
+		if !strings.Contains(r.errors[0], s) {
+			t.Errorf("The error should contain %q, got %q", s, r.errors[0])
+		}
+	}
+}
+
+func TestCheckHash(t *testing.T) {
+	c := comparer.New(comparer.IgnoreFields("A"), comparer.IgnoreUnexported())
+	consistent := func(v interface{}) uint64 {
+		return uint64(len(fmt.Sprintf("%v", v.(es2).F)))
+	}
+	r := &recorder{}
+	if !comparertest.CheckHash(r, c, consistent, reflect.TypeOf(es2{}), 500) || len(r.errors) != 0 {
+		t.Errorf("The check of a consistent hash should succeed, got %q", r.errors)
+	}
+
+	inconsistent := func(v interface{}) uint64 {
+		return uint64(v.(es2).A)
+	}
+	r = &recorder{}
+	if comparertest.CheckHash(r, c, inconsistent, reflect.TypeOf(es2{}), 500) || len(r.errors) != 1 {
+		t.Fatalf("The check of an inconsistent hash should fail with an error, got %q", r.errors)
+	}
+	if !strings.Contains(r.errors[0], "their hashes are different") {
+		t.Errorf("The error should describe the hashes, got %q", r.errors[0])
+	}
+}
+
+func FuzzInvariants(f *testing.F) {
+	comparertest.Fuzz(f, comparer.New(comparer.IgnoreUnexported()), reflect.TypeOf(es2{}))
+}
@@ -0,0 +1,124 @@
+package comparer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// A Change is the kind of a Difference.
+type Change int
+
+const (
+	// Modified means that the values exist in both sides, but they are not equal.
+	Modified Change = iota
+	// Added means that the value only exists in the second value, like a map entry or a slice element.
+	Added
+	// Removed means that the value only exists in the first value, like a map entry or a slice element.
+	Removed
+)
+
+// A Difference describes two values that are not equal.
+type Difference struct {
+	// Path is the path of the values, like "A.B[0]".
+	Path string
+	// Change is the kind of difference.
+	Change Change
+	// A and B are the values of each side, or nil when they do not exist or they can not be obtained.
+	A, B interface{}
+	// Reason describes why the values are different, like "different values" or "missing in the second value".
+	Reason string
+	// Steps are the steps of the path, that identify the fields, elements and map entries without parsing the path.
+	Steps []Step
+}
+
+// A state holds the settings and the results of a single traversal.
+type state struct {
+	exhaustive bool
+	subset     bool
+	report     bool
+	diffs      []Difference
+	reporter   Reporter
+	guard      bool
+	err        *IncomparableError
+	limits     *limits
+	workers    chan struct{}
+
+	visited     int
+	leaves      int
+	equalLeaves int
+}
+
+// String returns the name of the change.
+func (c Change) String() string {
+	switch c {
+	case Modified:
+		return "modified"
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	default:
+		return fmt.Sprintf("Change(%d)", int(c))
+	}
+}
+
+// Diff returns the differences between a and b, in the order they are found.
+// The comparison does not stop at the first difference, and the result is empty when the values are equal.
+func (c *Comparer) Diff(a interface{}, b interface{}) []Difference {
+	n := c.root(EqualOperation, a, b)
+	n.state = &state{exhaustive: true, report: true}
+	c.equalRoot(n, nil)
+	return n.state.diffs
+}
+
+// report records the difference of the node, if the traversal collects them. It always returns false.
+func (n *Node) report(change Change, reason string) bool {
+	if n.state != nil && n.state.report {
+		d := Difference{Path: n.Path, Change: change, A: interfaceOf(n.A), B: interfaceOf(n.B), Reason: reason, Steps: n.Steps()}
+		n.state.diffs = append(n.state.diffs, d)
+	}
+	return false
+}
+
+// probe returns the state of a comparison inside the traversal, that shares its limits and workers but does not collect the differences.
+func (n *Node) probe() *state {
+	s := &state{}
+	if n.state != nil {
+		s.guard = n.state.guard
+		s.limits = n.state.limits
+		s.workers = n.state.workers
+	}
+	return s
+}
+
+// proceed reports whether the traversal must continue after the result ok.
+func (n *Node) proceed(ok bool) bool {
+	return ok || n.exhaustive()
+}
+
+func (n *Node) exhaustive() bool {
+	return n.comparer.exhaustive || (n.state != nil && n.state.exhaustive)
+}
+
+func (n *Node) subset() bool {
+	return n.comparer.subset || (n.state != nil && n.state.subset)
+}
+
+// keys returns the keys of the map v. They are sorted by their representation when the traversal collects or reports the differences, so the result is stable.
+func (n *Node) keys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	if n.state != nil && (n.state.report || n.state.reporter != nil) {
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprintf("%v", keys[i].Interface()) < fmt.Sprintf("%v", keys[j].Interface())
+		})
+	}
+	return keys
+}
+
+func interfaceOf(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,108 @@
+package comparer_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+func TestDiff(t *testing.T) {
+	cases := map[string]struct {
+		a        interface{}
+		b        interface{}
+		expected []comparer.Difference
+	}{
+		"Equal": {
+			es3{es1{1, "test1"}, &es2{2, "test2"}},
+			es3{es1{1, "test1"}, &es2{2, "test2"}},
+			nil,
+		},
+		"Struct": {
+			es3{es1{1, "test1"}, &es2{2, "test2"}},
+			es3{es1{3, "test1"}, &es2{2, "test4"}},
+			[]comparer.Difference{
+				{Path: "A.A", Change: comparer.Modified, A: 1, B: 3},
+				{Path: "B.B", Change: comparer.Modified, A: "test2", B: "test4"},
+			},
+		},
+		"Nil": {
+			es3{es1{1, "test1"}, nil},
+			es3{es1{1, "test1"}, &es2{2, "test2"}},
+			[]comparer.Difference{
+				{Path: "B", Change: comparer.Modified, A: nil, B: es2{2, "test2"}},
+			},
+		},
+		"Slice": {
+			[]int{1, 2, 3},
+			[]int{1, 4},
+			[]comparer.Difference{
+				{Path: "[1]", Change: comparer.Modified, A: 2, B: 4},
+				{Path: "[2]", Change: comparer.Removed, A: 3, B: nil},
+			},
+		},
+		"SliceAdded": {
+			[]int{1},
+			[]int{1, 2},
+			[]comparer.Difference{
+				{Path: "[1]", Change: comparer.Added, A: nil, B: 2},
+			},
+		},
+		"Map": {
+			map[string]int{"a": 1, "b": 2, "c": 3},
+			map[string]int{"a": 1, "b": 4, "d": 5},
+			[]comparer.Difference{
+				{Path: "[b]", Change: comparer.Modified, A: 2, B: 4},
+				{Path: "[c]", Change: comparer.Removed, A: 3, B: nil},
+				{Path: "[d]", Change: comparer.Added, A: nil, B: 5},
+			},
+		},
+		"Type": {
+			es1{1, "test1"},
+			es2{1, "test1"},
+			[]comparer.Difference{
+				{Path: "", Change: comparer.Modified, A: es1{1, "test1"}, B: es2{1, "test1"}},
+			},
+		},
+	}
+
+	c := comparer.New()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diffs := summary(c.Diff(tc.a, tc.b))
+			if !reflect.DeepEqual(diffs, tc.expected) {
+				t.Errorf("The differences should be %+v, got %+v", tc.expected, diffs)
+			}
+			if c.Equal(tc.a, tc.b) != (len(diffs) == 0) {
+				t.Errorf("The differences should agree with Equal")
+			}
+		})
+	}
+}
+
+func TestDiffStable(t *testing.T) {
+	c := comparer.New()
+	a := map[int]int{}
+	b := map[int]int{}
+	for i := 0; i < 20; i++ {
+		a[i] = i
+		b[i] = -i
+	}
+
+	expected := fmt.Sprintf("%v", c.Diff(a, b))
+	for i := 0; i < 10; i++ {
+		if diffs := fmt.Sprintf("%v", c.Diff(a, b)); diffs != expected {
+			t.Fatalf("The differences should be stable, got %s and %s", expected, diffs)
+		}
+	}
+}
+
+// summary returns the differences without their reasons and steps.
+func summary(diffs []comparer.Difference) []comparer.Difference {
+	var result []comparer.Difference
+	for _, d := range diffs {
+		result = append(result, comparer.Difference{Path: d.Path, Change: d.Change, A: d.A, B: d.B})
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+package comparer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrNil is the reason of an IncomparableError when one of the values is nil.
+	ErrNil = errors.New("nil value")
+	// ErrTypeMismatch is the reason of an IncomparableError when the values have different types.
+	ErrTypeMismatch = errors.New("type mismatch")
+	// ErrUnsupportedKind is the reason of an IncomparableError when the values have a kind that can not be ordered, and no comparator compares them.
+	ErrUnsupportedKind = errors.New("unsupported kind")
+	// ErrPanic is the reason of an IncomparableError when a comparison panics, like a Comparator or a Transformer.
+	ErrPanic = errors.New("panic")
+)
+
+// An IncomparableError describes why two values can not be compared.
+// It can be inspected with errors.As, and its reason with errors.Is.
+type IncomparableError struct {
+	// Path is the path of the values, like "A.B[0]".
+	Path string
+	// KindA and KindB are the kinds of the values. The kind is reflect.Invalid when the value is nil.
+	KindA, KindB reflect.Kind
+	// TypeA and TypeB are the types of the values, or nil when the value is nil.
+	TypeA, TypeB reflect.Type
+	// Reason is one of the ErrNil, ErrTypeMismatch, ErrUnsupportedKind, ErrPanic and ErrLimitExceeded errors, or the error of the context that stopped the comparison.
+	Reason error
+	// Panic is the recovered value when the reason is ErrPanic.
+	Panic interface{}
+}
+
+// Error returns the description of the error.
+func (e *IncomparableError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "(root)"
+	}
+	msg := fmt.Sprintf("comparer: can not compare %s (%s and %s): %v", path, typeString(e.TypeA), typeString(e.TypeB), e.Reason)
+	if e.Panic != nil {
+		msg += fmt.Sprintf(": %v", e.Panic)
+	}
+	return msg
+}
+
+func typeString(t reflect.Type) string {
+	if t == nil {
+		return "nil"
+	}
+	return t.String()
+}
+
+// Unwrap returns the reason of the error.
+func (e *IncomparableError) Unwrap() error {
+	return e.Reason
+}
+
+// CompareE is like Compare, but it returns an *IncomparableError when the values are not comparable.
+// The panics of the comparison are recovered, and returned as an *IncomparableError with the path where they happened.
+func (c *Comparer) CompareE(a interface{}, b interface{}) (int, error) {
+	n := c.root(CompareOperation, a, b)
+	n.state = &state{guard: true}
+	return c.compareChecked(n, nil)
+}
+
+// EqualE is like Equal, but the panics of the comparison are recovered, and returned as an *IncomparableError with the path where they happened.
+func (c *Comparer) EqualE(a interface{}, b interface{}) (bool, error) {
+	n := c.root(EqualOperation, a, b)
+	n.state = &state{guard: true}
+	return c.equalRoot(n, nil)
+}
+
+// recoverError sets the error err to the recovered *IncomparableError that stopped the traversal, and panics again with the other values.
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		e, ok := r.(*IncomparableError)
+		if !ok {
+			panic(r)
+		}
+		*err = e
+	}
+}
+
+// guard converts a panic of the comparison of the node into an *IncomparableError with the node path.
+// The panics that are already converted by the nodes below are not changed.
+func (n *Node) guard() {
+	if r := recover(); r != nil {
+		if _, ok := r.(*IncomparableError); ok {
+			panic(r)
+		}
+		panic(n.incomparable(ErrPanic, r))
+	}
+}
+
+func (n *Node) guarded() bool {
+	return n.state != nil && n.state.guard
+}
+
+// fail records the reason why the values of the node are not comparable, and returns the result of an incomparable comparison.
+func (n *Node) fail(reason error) (int, bool) {
+	if n.state != nil {
+		n.state.err = n.incomparable(reason, nil)
+	}
+	return 0, false
+}
+
+func (n *Node) incomparable(reason error, value interface{}) *IncomparableError {
+	e := &IncomparableError{Path: n.Path, KindA: n.A.Kind(), KindB: n.B.Kind(), Reason: reason, Panic: value}
+	if n.A.IsValid() {
+		e.TypeA = n.A.Type()
+	}
+	if n.B.IsValid() {
+		e.TypeB = n.B.Type()
+	}
+	return e
+}
+
+// RecoverPanics returns a new Config that recovers the panics of the comparisons, like the panics of the Comparators and the Transformers.
+// The values of the node where the panic happened are not equal and not comparable,
+// and the difference reports the path and the types of the values. The other values are still compared.
+//
+// The CompareE and EqualE methods return the panics as errors, even with this configuration. The limits of the comparisons are not recovered.
+func RecoverPanics() Config {
+	return func(comp *Comparer) {
+		comp.safe = true
+	}
+}
+
+// unequal recovers a panic of the comparison of the node, and sets the result to false.
+func (n *Node) unequal(equal *bool) {
+	if r := recover(); r != nil {
+		if _, ok := r.(*IncomparableError); ok {
+			panic(r)
+		}
+		err := n.incomparable(ErrPanic, r)
+		*equal = n.report(Modified, err.Error())
+	}
+}
+
+// uncomparable recovers a panic of the comparison of the node, and sets the result to not comparable.
+func (n *Node) uncomparable(comparison *int, comparable *bool) {
+	if r := recover(); r != nil {
+		if _, ok := r.(*IncomparableError); ok {
+			panic(r)
+		}
+		if n.state != nil {
+			n.state.err = n.incomparable(ErrPanic, r)
+		}
+		*comparison, *comparable = 0, false
+	}
+}
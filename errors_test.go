@@ -0,0 +1,140 @@
+package comparer_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+func TestCompareE(t *testing.T) {
+	cases := map[string]struct {
+		config     comparer.Config
+		a          interface{}
+		b          interface{}
+		comparison int
+		reason     error
+		path       string
+		kinds      [2]reflect.Kind
+	}{
+		"Comparable": {
+			nil, 1, 2, -1, nil, "", [2]reflect.Kind{},
+		},
+		"Nil": {
+			nil, nil, 2, 0, comparer.ErrNil, "", [2]reflect.Kind{reflect.Invalid, reflect.Int},
+		},
+		"TypeMismatch": {
+			nil, 1, "a", 0, comparer.ErrTypeMismatch, "", [2]reflect.Kind{reflect.Int, reflect.String},
+		},
+		"UnsupportedKind": {
+			nil, es1{1, "a"}, es1{1, "a"}, 0, comparer.ErrUnsupportedKind, "", [2]reflect.Kind{reflect.Struct, reflect.Struct},
+		},
+		"Descend": {
+			comparer.CustomNodeComparator(func(n *comparer.Node, a, b interface{}) (int, bool) {
+				if v, ok := a.(es1); ok {
+					return n.Descend(".B", v.B, b.(es1).A)
+				}
+				return 0, false
+			}),
+			es1{1, "a"}, es1{1, "a"}, 0, comparer.ErrUnsupportedKind, "", [2]reflect.Kind{reflect.Struct, reflect.Struct},
+		},
+		"Panic": {
+			comparer.CustomNodeComparator(func(n *comparer.Node, a, b interface{}) (int, bool) {
+				if n.Path == "A.B" {
+					panic("boom")
+				}
+				return 0, false
+			}),
+			es3{A: es1{1, "a"}}, es3{A: es1{1, "b"}}, 0, comparer.ErrPanic, "A.B", [2]reflect.Kind{reflect.String, reflect.String},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var configs []comparer.Config
+			if tc.config != nil {
+				configs = append(configs, tc.config)
+			}
+			c := comparer.New(configs...)
+
+			var comparison int
+			var err error
+			if tc.reason == comparer.ErrPanic {
+				_, err = c.EqualE(tc.a, tc.b)
+			} else {
+				comparison, err = c.CompareE(tc.a, tc.b)
+			}
+			if tc.reason == nil {
+				if err != nil || comparison != tc.comparison {
+					t.Errorf("The comparison should be %d, got %d and %v", tc.comparison, comparison, err)
+				}
+				return
+			}
+
+			var ie *comparer.IncomparableError
+			if !errors.As(err, &ie) {
+				t.Fatalf("The error should be an IncomparableError, got %v", err)
+			}
+			if !errors.Is(err, tc.reason) {
+				t.Errorf("The reason should be %v, got %v", tc.reason, ie.Reason)
+			}
+			if ie.Path != tc.path || ie.KindA != tc.kinds[0] || ie.KindB != tc.kinds[1] {
+				t.Errorf("The error should be in %q with kinds %v, got %q with %v and %v", tc.path, tc.kinds, ie.Path, ie.KindA, ie.KindB)
+			}
+		})
+	}
+}
+
+func TestEqualE(t *testing.T) {
+	c := comparer.New()
+	if equal, err := c.EqualE(es1{1, "a"}, es1{1, "b"}); equal || err != nil {
+		t.Errorf("The values should not be equal without error, got %v and %v", equal, err)
+	}
+
+	c = comparer.New(comparer.Transform("fail", reflect.TypeOf(0), func(v interface{}) interface{} {
+		panic("transform")
+	}))
+	_, err := c.EqualE([]int{1}, []int{1})
+	var ie *comparer.IncomparableError
+	if !errors.As(err, &ie) || ie.Path != "[0]" || ie.Panic != "transform" {
+		t.Errorf("The panic should be recovered in [0], got %v", err)
+	}
+}
+
+func TestRecoverPanics(t *testing.T) {
+	boom := comparer.CustomNodeComparator(func(n *comparer.Node, a, b interface{}) (int, bool) {
+		if n.Path == "A.B" {
+			panic("boom")
+		}
+		return 0, false
+	})
+	c := comparer.New(boom, comparer.RecoverPanics())
+
+	a, b := es3{A: es1{1, "a"}, B: &es2{1, "a"}}, es3{A: es1{1, "a"}, B: &es2{2, "a"}}
+	if c.Equal(a, b) {
+		t.Errorf("The values should not be equal")
+	}
+	diffs := c.Diff(a, b)
+	if len(diffs) != 2 || diffs[0].Path != "A.B" || diffs[1].Path != "B.A" {
+		t.Fatalf("The differences should be in A.B and B.A, got %+v", diffs)
+	}
+	expected := `comparer: can not compare A.B (string and string): panic: boom`
+	if diffs[0].Reason != expected {
+		t.Errorf("The reason should be %q, got %q", expected, diffs[0].Reason)
+	}
+
+	if _, comparable := c.Compare(es1{1, "a"}, es1{1, "a"}); comparable {
+		t.Errorf("The structs should not be comparable")
+	}
+	if _, err := c.EqualE(a, b); !errors.Is(err, comparer.ErrPanic) {
+		t.Errorf("The panic should be returned as an error, got %v", err)
+	}
+
+	type unexported struct {
+		a int
+	}
+	if !comparer.New(comparer.RecoverPanics()).Equal(unexported{1}, unexported{1}) || comparer.New(comparer.RecoverPanics()).Equal(unexported{1}, unexported{2}) {
+		t.Errorf("The unexported fields should be compared")
+	}
+}
@@ -0,0 +1,124 @@
+package comparer
+
+import (
+	"bytes"
+	"reflect"
+)
+
+// primitive reports whether the elements of the arrays or slices of the node can be compared without visiting them,
+// because they have a primitive kind and no configuration can apply to them.
+func (c *Comparer) primitive(n *Node) bool {
+	if n.state != nil && (n.state.report || n.state.reporter != nil || n.state.exhaustive || n.state.limits != nil) {
+		return false
+	} else if c.exhaustive || c.c != nil || len(c.nodes) > 0 || len(c.matchers) > 0 {
+		return false
+	}
+
+	t := n.A.Type().Elem()
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+	case reflect.String:
+		if len(c.strings) > 0 {
+			return false
+		}
+	default:
+		return false
+	}
+	if _, ok := c.types[t]; ok {
+		return false
+	}
+	for _, tr := range c.trans {
+		if tr.t == nil || tr.t == t {
+			return false
+		}
+	}
+	return true
+}
+
+// equalPrimitives reports whether the arrays or slices a and b, with elements of a primitive kind, are equal.
+// The slices of the predeclared types are compared with typed loops, and the other ones through their kind.
+func equalPrimitives(a reflect.Value, b reflect.Value) bool {
+	if a.Len() != b.Len() {
+		return false
+	} else if a.Type().Elem().Kind() == reflect.Uint8 && a.Kind() == reflect.Slice {
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	}
+
+	if a.Kind() == reflect.Slice && a.CanInterface() {
+		switch x := a.Interface().(type) {
+		case []int:
+			y := b.Interface().([]int)
+			for i := range x {
+				if x[i] != y[i] {
+					return false
+				}
+			}
+			return true
+		case []int64:
+			y := b.Interface().([]int64)
+			for i := range x {
+				if x[i] != y[i] {
+					return false
+				}
+			}
+			return true
+		case []uint64:
+			y := b.Interface().([]uint64)
+			for i := range x {
+				if x[i] != y[i] {
+					return false
+				}
+			}
+			return true
+		case []float64:
+			y := b.Interface().([]float64)
+			for i := range x {
+				if x[i] != y[i] {
+					return false
+				}
+			}
+			return true
+		case []string:
+			y := b.Interface().([]string)
+			for i := range x {
+				if x[i] != y[i] {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	for i := 0; i < a.Len(); i++ {
+		x, y := a.Index(i), b.Index(i)
+		switch x.Kind() {
+		case reflect.Bool:
+			if x.Bool() != y.Bool() {
+				return false
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if x.Int() != y.Int() {
+				return false
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if x.Uint() != y.Uint() {
+				return false
+			}
+		case reflect.Float32, reflect.Float64:
+			if x.Float() != y.Float() {
+				return false
+			}
+		case reflect.Complex64, reflect.Complex128:
+			if x.Complex() != y.Complex() {
+				return false
+			}
+		case reflect.String:
+			if x.String() != y.String() {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package comparer_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type celsius float64
+
+type es17 struct {
+	Values []celsius
+}
+
+func TestPrimitives(t *testing.T) {
+	cases := map[string]struct {
+		a interface{}
+		b interface{}
+	}{
+		"Bytes":        {[]byte("abc"), []byte("abd")},
+		"Ints":         {[]int{1, 2, 3}, []int{1, 2, 4}},
+		"Int64s":       {[]int64{1, 2, 3}, []int64{1, 2, 4}},
+		"Uint64s":      {[]uint64{1, 2, 3}, []uint64{1, 2, 4}},
+		"Float64s":     {[]float64{1, 2, 3}, []float64{1, 2, 4}},
+		"NaN":          {[]float64{math.NaN()}, []float64{math.NaN()}},
+		"Zeros":        {[]float64{0}, []float64{math.Copysign(0, -1)}},
+		"Strings":      {[]string{"a", "b"}, []string{"a", "c"}},
+		"Bools":        {[]bool{true, false}, []bool{true, true}},
+		"Complex":      {[]complex128{1 + 2i}, []complex128{1 + 3i}},
+		"Int8s":        {[]int8{1, 2}, []int8{1, 3}},
+		"Named":        {es17{[]celsius{1, 2}}, es17{[]celsius{1, 3}}},
+		"Array":        {[3]float64{1, 2, 3}, [3]float64{1, 2, 4}},
+		"ByteArray":    {[2]byte{1, 2}, [2]byte{1, 3}},
+		"Length":       {[]int{1, 2}, []int{1, 2, 3}},
+		"NilAndEmpty":  {[]int(nil), []int{}},
+		"EqualStrings": {[]string{"a", "b"}, []string{"a", "b"}},
+	}
+
+	fast := comparer.New()
+	general := comparer.New(comparer.CustomNodeComparator(func(n *comparer.Node, a, b interface{}) (int, bool) {
+		return 0, false
+	}))
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, v := range [][2]interface{}{{tc.a, tc.a}, {tc.a, tc.b}, {tc.b, tc.a}} {
+				if fast.Equal(v[0], v[1]) != general.Equal(v[0], v[1]) {
+					t.Errorf("The fast path should agree with the general path for %v and %v", v[0], v[1])
+				}
+			}
+		})
+	}
+}
+
+func TestPrimitivesConfigured(t *testing.T) {
+	cases := map[string]comparer.Config{
+		"TypeComparator": comparer.TypeComparator(reflect.TypeOf(0.0), func(path string, a, b interface{}) (int, bool) {
+			if math.Abs(a.(float64)-b.(float64)) < 0.1 {
+				return 0, true
+			}
+			return 1, true
+		}),
+		"Comparator": comparer.CustomComparator(func(path string, a, b interface{}) (int, bool) {
+			if x, ok := a.(float64); ok {
+				if math.Abs(x-b.(float64)) < 0.1 {
+					return 0, true
+				}
+			}
+			return 0, false
+		}),
+		"Transform": comparer.Transform("round", reflect.TypeOf(0.0), func(v interface{}) interface{} {
+			return math.Round(v.(float64))
+		}),
+		"Matcher": func(c *comparer.Comparer) {
+			comparer.PathMatcher("[0]", comparer.Any())(c)
+			comparer.PathMatcher("[1]", comparer.Any())(c)
+		},
+	}
+
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			if !comparer.New(config).Equal([]float64{1, 2}, []float64{1.01, 2.01}) {
+				t.Errorf("The configuration should apply to the elements")
+			}
+		})
+	}
+}
+
+func benchmarkPrimitives(b *testing.B, x interface{}, y interface{}, general bool) {
+	var configs []comparer.Config
+	if general {
+		configs = append(configs, comparer.CustomNodeComparator(func(n *comparer.Node, a, b interface{}) (int, bool) {
+			return 0, false
+		}))
+	}
+	c := comparer.New(configs...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Equal(x, y)
+	}
+}
+
+func BenchmarkEqualBytes(b *testing.B) {
+	x, y := make([]byte, 10000), make([]byte, 10000)
+	b.Run("Fast", func(b *testing.B) { benchmarkPrimitives(b, x, y, false) })
+	b.Run("General", func(b *testing.B) { benchmarkPrimitives(b, x, y, true) })
+}
+
+func BenchmarkEqualInt64s(b *testing.B) {
+	x, y := make([]int64, 10000), make([]int64, 10000)
+	b.Run("Fast", func(b *testing.B) { benchmarkPrimitives(b, x, y, false) })
+	b.Run("General", func(b *testing.B) { benchmarkPrimitives(b, x, y, true) })
+}
+
+func BenchmarkEqualFloat64Array(b *testing.B) {
+	x, y := [1000]float64{}, [1000]float64{}
+	b.Run("Fast", func(b *testing.B) { benchmarkPrimitives(b, x, y, false) })
+	b.Run("General", func(b *testing.B) { benchmarkPrimitives(b, x, y, true) })
+}
@@ -0,0 +1,95 @@
+package comparer
+
+import "reflect"
+
+// A fieldFilter reports whether a struct field must be ignored.
+type fieldFilter func(f reflect.StructField, a reflect.Value, b reflect.Value) bool
+
+// An entryFilter reports whether a map entry must be ignored. The value is invalid when the entry does not exist.
+type entryFilter func(k reflect.Value, v reflect.Value) bool
+
+// IgnoreFields returns a new Config that ignores the struct fields with any of the provided names, in any struct.
+func IgnoreFields(names ...string) Config {
+	return func(comp *Comparer) {
+		comp.fields = append(comp.fields, func(f reflect.StructField, _ reflect.Value, _ reflect.Value) bool {
+			for _, name := range names {
+				if f.Name == name {
+					return true
+				}
+			}
+			return false
+		})
+	}
+}
+
+// IgnoreTypes returns a new Config that ignores the struct fields and the map entries whose values have any of the provided types.
+// The dynamic type of the interface values is also taken into account.
+func IgnoreTypes(types ...reflect.Type) Config {
+	ignored := func(v reflect.Value) bool {
+		if !v.IsValid() {
+			return false
+		}
+		for _, t := range types {
+			if v.Type() == t || (v.Kind() == reflect.Interface && !v.IsNil() && v.Elem().Type() == t) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(comp *Comparer) {
+		comp.fields = append(comp.fields, func(_ reflect.StructField, a reflect.Value, b reflect.Value) bool {
+			return ignored(a) || ignored(b)
+		})
+		comp.entries = append(comp.entries, func(_ reflect.Value, v reflect.Value) bool {
+			return ignored(v)
+		})
+	}
+}
+
+// IgnoreUnexported returns a new Config that ignores the unexported struct fields.
+// The types that only have unexported fields, like time.Time, need a comparator to not be always equal.
+func IgnoreUnexported() Config {
+	return func(comp *Comparer) {
+		comp.fields = append(comp.fields, func(f reflect.StructField, _ reflect.Value, _ reflect.Value) bool {
+			return !f.IsExported()
+		})
+	}
+}
+
+// IgnoreZeroOnLeft returns a new Config that ignores the struct fields whose value is zero in the first value.
+// It allows to compare a partially filled value with a complete one.
+func IgnoreZeroOnLeft() Config {
+	return func(comp *Comparer) {
+		comp.fields = append(comp.fields, func(_ reflect.StructField, a reflect.Value, _ reflect.Value) bool {
+			return a.IsZero()
+		})
+	}
+}
+
+// IgnoreMapEntries returns a new Config that ignores the map entries for which the predicate returns true.
+// An entry is ignored when the predicate returns true for the entry of any of the two maps.
+func IgnoreMapEntries(predicate func(key interface{}, value interface{}) bool) Config {
+	return func(comp *Comparer) {
+		comp.entries = append(comp.entries, func(k reflect.Value, v reflect.Value) bool {
+			return v.IsValid() && predicate(k.Interface(), v.Interface())
+		})
+	}
+}
+
+func (c *Comparer) ignoreField(f reflect.StructField, a reflect.Value, b reflect.Value) bool {
+	for _, filter := range c.fields {
+		if filter(f, a, b) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Comparer) ignoreEntry(k reflect.Value, a reflect.Value, b reflect.Value) bool {
+	for _, filter := range c.entries {
+		if filter(k, a) || filter(k, b) {
+			return true
+		}
+	}
+	return false
+}
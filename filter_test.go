@@ -0,0 +1,112 @@
+package comparer_test
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es8 struct {
+	Name      string
+	CreatedAt time.Time
+	Mutex     sync.Mutex
+	Child     *es8
+	Extra     map[string]interface{}
+	private   int
+}
+
+func TestIgnore(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	cases := map[string]struct {
+		configs []comparer.Config
+		a       interface{}
+		b       interface{}
+		equal   bool
+	}{
+		"Fields": {
+			[]comparer.Config{comparer.IgnoreFields("CreatedAt"), comparer.IgnoreUnexported()},
+			&es8{Name: "a", CreatedAt: now, Child: &es8{Name: "b", CreatedAt: now}},
+			&es8{Name: "a", CreatedAt: later, Child: &es8{Name: "b", CreatedAt: later}},
+			true,
+		},
+		"FieldsDifferent": {
+			[]comparer.Config{comparer.IgnoreFields("CreatedAt"), comparer.IgnoreUnexported()},
+			&es8{Name: "a", CreatedAt: now},
+			&es8{Name: "b", CreatedAt: later},
+			false,
+		},
+		"Types": {
+			[]comparer.Config{comparer.IgnoreTypes(reflect.TypeOf(time.Time{}), reflect.TypeOf(sync.Mutex{})), comparer.IgnoreUnexported()},
+			&es8{Name: "a", CreatedAt: now, Extra: map[string]interface{}{"at": now}},
+			&es8{Name: "a", CreatedAt: later, Extra: map[string]interface{}{"at": later}},
+			true,
+		},
+		"Unexported": {
+			[]comparer.Config{comparer.IgnoreUnexported(), comparer.IgnoreFields("CreatedAt", "Mutex")},
+			es8{Name: "a", private: 1},
+			es8{Name: "a", private: 2},
+			true,
+		},
+		"ZeroOnLeft": {
+			[]comparer.Config{comparer.IgnoreZeroOnLeft(), comparer.IgnoreUnexported()},
+			es8{Name: "a"},
+			es8{Name: "a", CreatedAt: now, Child: &es8{Name: "b"}, private: 2},
+			true,
+		},
+		"ZeroOnLeftDifferent": {
+			[]comparer.Config{comparer.IgnoreZeroOnLeft(), comparer.IgnoreUnexported()},
+			es8{Name: "b"},
+			es8{Name: "a", CreatedAt: now},
+			false,
+		},
+		"ZeroOnRight": {
+			[]comparer.Config{comparer.IgnoreZeroOnLeft(), comparer.IgnoreUnexported(), comparer.TimeComparator(0)},
+			es8{Name: "a", CreatedAt: now},
+			es8{Name: "a"},
+			false,
+		},
+		"MapEntries": {
+			[]comparer.Config{comparer.IgnoreMapEntries(func(k interface{}, _ interface{}) bool {
+				return strings.HasPrefix(k.(string), "_")
+			})},
+			map[string]int{"a": 1, "_b": 2},
+			map[string]int{"a": 1, "_c": 3},
+			true,
+		},
+		"MapEntriesDifferent": {
+			[]comparer.Config{comparer.IgnoreMapEntries(func(k interface{}, _ interface{}) bool {
+				return strings.HasPrefix(k.(string), "_")
+			})},
+			map[string]int{"a": 1, "_b": 2},
+			map[string]int{"a": 1, "c": 3},
+			false,
+		},
+		"MapEntriesValue": {
+			[]comparer.Config{comparer.IgnoreMapEntries(func(_ interface{}, v interface{}) bool {
+				return v.(int) == 0
+			})},
+			map[string]int{"a": 1, "b": 0},
+			map[string]int{"a": 1, "c": 0},
+			true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := comparer.New(tc.configs...)
+			if c.Equal(tc.a, tc.b) != tc.equal {
+				if tc.equal {
+					t.Errorf("The values should be equal")
+				} else {
+					t.Errorf("The values should not be equal")
+				}
+			}
+		})
+	}
+}
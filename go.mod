@@ -1,3 +1,5 @@
 module github.com/gum-dev-ar/comparer
 
-go 1.16
+go 1.18
+
+require golang.org/x/text v0.14.0
@@ -0,0 +1,216 @@
+package comparer
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errNotEncoded is the error of the differences in the struct fields that are not encoded as JSON.
+var errNotEncoded = errors.New("the field is not encoded as JSON")
+
+// Pointer returns the path of the difference as a JSON Pointer (RFC 6901), like "/A/B/0", following the JSON encoding of the values.
+// The struct fields use the names of their json tags, if they have one, the fields of the embedded structs without a name are part of
+// the enclosing struct, and the map keys use their text representation. The byte slices are encoded as strings, so the pointer of a byte
+// is the pointer of its slice. The fields tagged with "-" are not encoded, so they use their name.
+//
+// The transformers are not part of the JSON values, so their steps are left out, and the pointer of a transformed value points to
+// the value before the transformation, or inside it.
+func (d Difference) Pointer() string {
+	p, _ := d.pointer()
+	return p
+}
+
+// pointer returns the JSON Pointer of the difference, and an error if the difference is not a whole value of the JSON encoding.
+func (d Difference) pointer() (string, error) {
+	var sb strings.Builder
+	var err error
+	for _, s := range d.Steps {
+		switch {
+		case s.Kind == TransformStep:
+			continue
+		case s.Kind == FieldStep && s.Field != nil && s.Field.Tag.Get("json") == "-":
+			err = errNotEncoded
+		case s.Kind == FieldStep && s.Field != nil && flattened(*s.Field):
+			continue
+		case s.Kind == IndexStep && s.bytes:
+			if err == nil {
+				err = fmt.Errorf("the byte %d is inside a base64 string", s.Index)
+			}
+			return sb.String(), err
+		}
+		sb.WriteString("/")
+		sb.WriteString(escapePointer(s.token()))
+	}
+	return sb.String(), err
+}
+
+// flattened reports whether the fields of the struct field f are encoded in the enclosing struct, because it is an embedded struct without a json name.
+func flattened(f reflect.StructField) bool {
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return f.Anonymous && t.Kind() == reflect.Struct && strings.Split(f.Tag.Get("json"), ",")[0] == ""
+}
+
+// token returns the representation of the step in a JSON Pointer, without escaping.
+func (s Step) token() string {
+	switch s.Kind {
+	case FieldStep:
+		if s.Field != nil {
+			if name := strings.Split(s.Field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+				return name
+			}
+		}
+		return s.Name
+	case IndexStep:
+		return strconv.Itoa(s.Index)
+	case KeyStep:
+		if m, ok := s.Key.(encoding.TextMarshaler); ok {
+			if text, err := m.MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+		return fmt.Sprintf("%v", s.Key)
+	default:
+		return strings.TrimSuffix(strings.TrimLeft(s.Name, ".["), "]")
+	}
+}
+
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// JSONPatch returns the differences as the operations of a JSON Patch (RFC 6902), that transforms the first value into the second one.
+// The modified values are replaced, the added values are added and the removed values are removed.
+// The operations of the slice elements are sorted, so they can be applied in order.
+//
+// The differences in the fields tagged with "-" are left out, because they are not encoded.
+//
+// It returns an error if a value can not be encoded as JSON, or if a difference is in a transformed value, because the transformed values
+// are not part of the JSON values. It also returns an error for the differences of the elements of the byte slices, that are encoded as
+// base64 strings: the BytesComparator configuration compares the byte slices as a whole.
+func JSONPatch(diffs []Difference) ([]byte, error) {
+	ops := []map[string]interface{}{}
+	for _, d := range ordered(diffs) {
+		for _, s := range d.Steps {
+			if s.Kind == TransformStep {
+				return nil, fmt.Errorf("comparer: can not patch %q: the step %q is not part of the value", d.Path, s.Name)
+			}
+		}
+		p, err := d.pointer()
+		if errors.Is(err, errNotEncoded) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("comparer: can not patch %q: %w", d.Path, err)
+		}
+		switch d.Change {
+		case Added:
+			ops = append(ops, map[string]interface{}{"op": "add", "path": p, "value": d.B})
+		case Removed:
+			ops = append(ops, map[string]interface{}{"op": "remove", "path": p})
+		default:
+			ops = append(ops, map[string]interface{}{"op": "replace", "path": p, "value": d.B})
+		}
+	}
+	return json.Marshal(ops)
+}
+
+// ordered returns the differences sorted so they can be applied in order.
+// The changes inside the elements of each slice are moved first, followed by the removed elements from the last to the first, and then the added elements from the first to the last.
+func ordered(diffs []Difference) []Difference {
+	return orderedFrom(diffs, 0)
+}
+
+// orderedFrom sorts the differences that share the steps before the depth.
+func orderedFrom(diffs []Difference, depth int) []Difference {
+	var result []Difference
+	for i := 0; i < len(diffs); {
+		d := diffs[i]
+		if len(d.Steps) <= depth {
+			result = append(result, d)
+			i++
+			continue
+		}
+
+		// The group has the differences of the same slice, or of the same step.
+		elements := d.Steps[depth].Kind == IndexStep
+		j := i + 1
+		for ; j < len(diffs); j++ {
+			e := diffs[j]
+			if len(e.Steps) <= depth || !within(d.Steps[:depth], e.Steps) {
+				break
+			} else if elements && e.Steps[depth].Kind != IndexStep {
+				break
+			} else if !elements && !sameStep(d.Steps[depth], e.Steps[depth]) {
+				break
+			}
+		}
+		group := diffs[i:j]
+		i = j
+
+		if !elements {
+			result = append(result, orderedFrom(group, depth+1)...)
+			continue
+		}
+		var removed, added, rest []Difference
+		for _, e := range group {
+			if len(e.Steps) == depth+1 && e.Change == Removed {
+				removed = append(removed, e)
+			} else if len(e.Steps) == depth+1 && e.Change == Added {
+				added = append(added, e)
+			} else {
+				rest = append(rest, e)
+			}
+		}
+		sort.SliceStable(removed, func(a, b int) bool {
+			return removed[a].Steps[depth].Index > removed[b].Steps[depth].Index
+		})
+		sort.SliceStable(added, func(a, b int) bool {
+			return added[a].Steps[depth].Index < added[b].Steps[depth].Index
+		})
+		result = append(result, orderedFrom(rest, depth+1)...)
+		result = append(result, removed...)
+		result = append(result, added...)
+	}
+	return result
+}
+
+// A reportEntry is an element of the JSON report.
+type reportEntry struct {
+	Path    string      `json:"path"`
+	Pointer string      `json:"pointer"`
+	Kind    string      `json:"kind"`
+	Left    interface{} `json:"left"`
+	Right   interface{} `json:"right"`
+	Reason  string      `json:"reason"`
+}
+
+// JSONReport returns the differences as a JSON array of objects with the path, the JSON Pointer, the kind of change, the left and right values and the reason.
+// The values that do not exist are null, and the Matchers are represented by their String method.
+//
+// It returns an error if a value can not be encoded as JSON.
+func JSONReport(diffs []Difference) ([]byte, error) {
+	entries := make([]reportEntry, 0, len(diffs))
+	for _, d := range diffs {
+		left := d.A
+		if m, ok := left.(Matcher); ok {
+			left = m.String()
+		}
+		entries = append(entries, reportEntry{
+			Path:    d.Path,
+			Pointer: d.Pointer(),
+			Kind:    d.Change.String(),
+			Left:    left,
+			Right:   d.B,
+			Reason:  d.Reason,
+		})
+	}
+	return json.Marshal(entries)
+}
@@ -0,0 +1,178 @@
+package comparer_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es12 struct {
+	ID     int               `json:"id"`
+	Name   string            `json:"name,omitempty"`
+	Tags   []string          `json:"tags"`
+	Labels map[string]string `json:"labels"`
+	Note   string
+}
+
+type es19 struct {
+	es1
+	Hidden string `json:"-"`
+	Data   []byte `json:"data"`
+	Named  es1    `json:"named"`
+}
+
+func TestDifferencePointer(t *testing.T) {
+	cases := map[string]struct {
+		a        interface{}
+		b        interface{}
+		expected []string
+	}{
+		"Root": {
+			1,
+			2,
+			[]string{""},
+		},
+		"Tags": {
+			es12{ID: 1, Tags: []string{"a", "b"}, Note: "x"},
+			es12{ID: 2, Tags: []string{"a", "c"}, Note: "y"},
+			[]string{"/id", "/tags/1", "/Note"},
+		},
+		"Escaped": {
+			map[string]int{"a/b": 1, "c~d": 2},
+			map[string]int{"a/b": 3, "c~d": 4},
+			[]string{"/a~1b", "/c~0d"},
+		},
+		"Nested": {
+			[]es12{{Labels: map[string]string{"k": "v"}}},
+			[]es12{{Labels: map[string]string{"k": "w"}}},
+			[]string{"/0/labels/k"},
+		},
+		"Embedded": {
+			es19{es1: es1{1, "a"}, Named: es1{1, "a"}},
+			es19{es1: es1{2, "a"}, Named: es1{2, "a"}},
+			[]string{"/A", "/named/A"},
+		},
+		"Bytes": {
+			es19{Data: []byte("ab")},
+			es19{Data: []byte("ac")},
+			[]string{"/data"},
+		},
+	}
+
+	c := comparer.New()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diffs := c.Diff(tc.a, tc.b)
+			if len(diffs) != len(tc.expected) {
+				t.Fatalf("The differences should be %d, got %+v", len(tc.expected), diffs)
+			}
+			for i, d := range diffs {
+				if d.Pointer() != tc.expected[i] {
+					t.Errorf("The pointer should be %q, got %q", tc.expected[i], d.Pointer())
+				}
+			}
+		})
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	cases := map[string]struct {
+		a        interface{}
+		b        interface{}
+		expected string
+	}{
+		"Equal": {
+			es12{ID: 1},
+			es12{ID: 1},
+			`[]`,
+		},
+		"Replace": {
+			es12{ID: 1, Name: "a"},
+			es12{ID: 1, Name: "b"},
+			`[{"op":"replace","path":"/name","value":"b"}]`,
+		},
+		"Add": {
+			es12{Labels: map[string]string{"k": "v"}},
+			es12{Labels: map[string]string{"k": "v", "l": "w"}},
+			`[{"op":"add","path":"/labels/l","value":"w"}]`,
+		},
+		"Remove": {
+			es12{Tags: []string{"a", "b", "c", "d"}},
+			es12{Tags: []string{"x", "b"}},
+			`[{"op":"replace","path":"/tags/0","value":"x"},{"op":"remove","path":"/tags/3"},{"op":"remove","path":"/tags/2"}]`,
+		},
+	}
+
+	c := comparer.New()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			patch, err := comparer.JSONPatch(c.Diff(tc.a, tc.b))
+			if err != nil {
+				t.Fatalf("The patch should be encoded, got %v", err)
+			}
+			if string(patch) != tc.expected {
+				t.Errorf("The patch should be %s, got %s", tc.expected, patch)
+			}
+		})
+	}
+}
+
+func TestJSONPatchEncoding(t *testing.T) {
+	c := comparer.New()
+	patch, err := comparer.JSONPatch(c.Diff(es19{es1: es1{1, "a"}, Hidden: "a"}, es19{es1: es1{2, "a"}, Hidden: "b"}))
+	if err != nil {
+		t.Fatalf("The patch should be encoded, got %v", err)
+	}
+	if expected := `[{"op":"replace","path":"/A","value":2}]`; string(patch) != expected {
+		t.Errorf("The patch should be %s, got %s", expected, patch)
+	}
+
+	if patch, err := comparer.JSONPatch(c.Diff(es19{Data: []byte("ab")}, es19{Data: []byte("ac")})); err == nil {
+		t.Errorf("The differences in the bytes should not be patched, got %s", patch)
+	}
+	patch, err = comparer.JSONPatch(comparer.New(comparer.BytesComparator()).Diff(es19{Data: []byte("ab")}, es19{Data: []byte("ac")}))
+	if expected := `[{"op":"replace","path":"/data","value":"YWM="}]`; err != nil || string(patch) != expected {
+		t.Errorf("The patch should be %s, got %s and %v", expected, patch, err)
+	}
+}
+
+func TestJSONPatchTransformed(t *testing.T) {
+	c := comparer.New(comparer.Transform("sort", reflect.TypeOf([]string(nil)), func(v interface{}) interface{} {
+		s := append([]string(nil), v.([]string)...)
+		sort.Strings(s)
+		return s
+	}))
+
+	diffs := c.Diff(es12{Tags: []string{"b", "a"}}, es12{Tags: []string{"c", "a"}})
+	if len(diffs) == 0 {
+		t.Fatalf("The values should be different")
+	}
+	if patch, err := comparer.JSONPatch(diffs); err == nil {
+		t.Errorf("The differences in the transformed values should not be patched, got %s", patch)
+	}
+}
+
+func TestJSONReport(t *testing.T) {
+	c := comparer.New()
+	a := []interface{}{1, comparer.NotZero(), "a"}
+	b := []interface{}{2, 0}
+
+	report, err := comparer.JSONReport(c.Diff(a, b))
+	if err != nil {
+		t.Fatalf("The report should be encoded, got %v", err)
+	}
+	expected := `[` +
+		`{"path":"[0]","pointer":"/0","kind":"modified","left":1,"right":2,"reason":"different values"},` +
+		`{"path":"[1]","pointer":"/1","kind":"modified","left":"NotZero()","right":0,"reason":"does not match NotZero()"},` +
+		`{"path":"[2]","pointer":"/2","kind":"removed","left":"a","right":null,"reason":"missing in the second value"}` +
+		`]`
+	if string(report) != expected {
+		t.Errorf("The report should be %s, got %s", expected, report)
+	}
+
+	if _, err := comparer.JSONReport(c.Diff(1, func() {})); err == nil {
+		t.Errorf("The report should fail for values that can not be encoded")
+	}
+}
@@ -0,0 +1,126 @@
+package comparer
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type sliceKey struct {
+	name  string
+	t     reflect.Type
+	field bool
+	key   KeyFunc
+	paths scope
+}
+
+// SliceKey returns a new Config that matches the elements of the slices by the identity returned by the KeyFunc key, instead of by their position.
+// It applies to the slices with elements of type t, or to the slices of any type if t is nil. If no paths are provided, it applies to all the paths.
+//
+// The matched elements are compared, and the elements whose key is only in one of the slices are reported as removed or added, so the order of the elements is ignored.
+// The path of the elements has the name and the key, like "Users[ID=42].Name", and their index is the index in the first value, except for the added elements.
+// The keys must be comparable, and the elements with repeated keys are matched in order.
+func SliceKey(name string, t reflect.Type, key KeyFunc, paths ...string) Config {
+	return func(comp *Comparer) {
+		comp.keys = append(comp.keys, sliceKey{name: name, t: t, key: key, paths: paths})
+	}
+}
+
+// SliceKeyField returns a new Config that matches the elements of the slices of structs, or pointers to structs, by the value of the field with the name.
+// If no paths are provided, it applies to all the slices with elements that have the field.
+func SliceKeyField(field string, paths ...string) Config {
+	key := func(v interface{}) interface{} {
+		rv := reflect.ValueOf(v)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return nil
+		}
+		f := rv.FieldByName(field)
+		if !f.IsValid() || !f.CanInterface() {
+			return nil
+		}
+		return f.Interface()
+	}
+	return func(comp *Comparer) {
+		comp.keys = append(comp.keys, sliceKey{name: field, field: true, key: key, paths: paths})
+	}
+}
+
+// sliceKey returns the last configured key that applies to the slices of the node.
+func (c *Comparer) sliceKey(n *Node) (*sliceKey, bool) {
+	var k *sliceKey
+	for i := range c.keys {
+		if c.keys[i].paths.match(n.Path) && c.keys[i].applies(n.A.Type().Elem()) {
+			k = &c.keys[i]
+		}
+	}
+	return k, k != nil
+}
+
+// applies reports whether the key applies to the elements of type t.
+func (k *sliceKey) applies(t reflect.Type) bool {
+	if k.field {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return false
+		}
+		_, ok := t.FieldByName(k.name)
+		return ok
+	}
+	return k.t == nil || k.t == t
+}
+
+// matchKeys compares the elements of the slices of the node that have the same key.
+func (c *Comparer) matchKeys(n *Node, k *sliceKey) bool {
+	a, b := n.A, n.B
+	if !n.subset() && a.IsNil() != b.IsNil() {
+		return n.report(Modified, "one of the values is nil")
+	}
+	if !n.subset() && !n.exhaustive() && a.Len() != b.Len() {
+		return false
+	}
+
+	indexes := map[interface{}][]int{}
+	for j := 0; j < b.Len(); j++ {
+		key := k.key(c.value(b.Index(j)))
+		indexes[key] = append(indexes[key], j)
+	}
+	matched := make([]bool, b.Len())
+
+	ok := true
+	for i := 0; i < a.Len() && n.proceed(ok); i++ {
+		key := k.key(c.value(a.Index(i)))
+		var m *Node
+		if js := indexes[key]; len(js) > 0 {
+			indexes[key] = js[1:]
+			matched[js[0]] = true
+			m = n.keyedChild(k, key, i, a.Index(i), b.Index(js[0]))
+		} else {
+			m = n.keyedChild(k, key, i, a.Index(i), reflect.Value{})
+		}
+		ok = c.equal(m) && ok
+	}
+	for j := 0; j < b.Len() && n.proceed(ok) && !n.subset(); j++ {
+		if !matched[j] {
+			ok = c.equal(n.keyedChild(k, k.key(c.value(b.Index(j))), j, reflect.Value{}, b.Index(j))) && ok
+		}
+	}
+	return ok
+}
+
+// keyedChild returns the node of the elements with the key, as the elements with index i.
+func (n *Node) keyedChild(k *sliceKey, key interface{}, i int, a reflect.Value, b reflect.Value) *Node {
+	label := fmt.Sprintf("%v", key)
+	if k.name != "" {
+		label = k.name + "=" + label
+	}
+	c := n.child(n.Path+"["+label+"]", a, b)
+	if n.detailed() {
+		c.step = &Step{Kind: IndexStep, Index: i, Name: label, Key: key}
+	}
+	c.entry = true
+	return c
+}
@@ -0,0 +1,102 @@
+package comparer_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es15 struct {
+	ID    int
+	Name  string
+	Users []*es14
+}
+
+func TestSliceKey(t *testing.T) {
+	id := func(v interface{}) interface{} {
+		return v.(es14).ID
+	}
+
+	cases := map[string]struct {
+		config   comparer.Config
+		a        interface{}
+		b        interface{}
+		expected []comparer.Difference
+	}{
+		"Equal": {
+			comparer.SliceKey("ID", reflect.TypeOf(es14{}), id),
+			[]es14{{1, "a"}, {2, "b"}},
+			[]es14{{2, "b"}, {1, "a"}},
+			nil,
+		},
+		"Changed": {
+			comparer.SliceKey("ID", reflect.TypeOf(es14{}), id),
+			[]es14{{1, "a"}, {42, "b"}},
+			[]es14{{42, "c"}, {1, "a"}},
+			[]comparer.Difference{
+				{Path: "[ID=42].Name", Change: comparer.Modified, A: "b", B: "c"},
+			},
+		},
+		"Missing": {
+			comparer.SliceKey("ID", reflect.TypeOf(es14{}), id),
+			[]es14{{1, "a"}, {2, "b"}},
+			[]es14{{3, "c"}, {1, "a"}},
+			[]comparer.Difference{
+				{Path: "[ID=2]", Change: comparer.Removed, A: es14{2, "b"}, B: nil},
+				{Path: "[ID=3]", Change: comparer.Added, A: nil, B: es14{3, "c"}},
+			},
+		},
+		"Field": {
+			comparer.SliceKeyField("ID"),
+			es15{ID: 1, Users: []*es14{{1, "a"}, {2, "b"}}},
+			es15{ID: 1, Users: []*es14{{2, "c"}, {1, "a"}}},
+			[]comparer.Difference{
+				{Path: "Users[ID=2].Name", Change: comparer.Modified, A: "b", B: "c"},
+			},
+		},
+		"Type": {
+			comparer.SliceKey("ID", reflect.TypeOf(es14{}), id),
+			[]int{1, 2},
+			[]int{2, 1},
+			[]comparer.Difference{
+				{Path: "[0]", Change: comparer.Modified, A: 1, B: 2},
+				{Path: "[1]", Change: comparer.Modified, A: 2, B: 1},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := comparer.New(tc.config)
+			diffs := c.Diff(tc.a, tc.b)
+			if !reflect.DeepEqual(summary(diffs), tc.expected) {
+				t.Errorf("The differences should be %+v, got %+v", tc.expected, summary(diffs))
+			}
+			if c.Equal(tc.a, tc.b) != (len(tc.expected) == 0) {
+				t.Errorf("The differences should agree with Equal")
+			}
+
+			patched := reflect.New(reflect.TypeOf(tc.a))
+			patched.Elem().Set(reflect.ValueOf(tc.a))
+			if err := comparer.Patch(patched.Interface(), diffs); err != nil {
+				t.Fatalf("The differences should be applied, got %v", err)
+			}
+			if !c.Equal(patched.Elem().Interface(), tc.b) {
+				t.Errorf("The patched value should be %v, got %v", tc.b, patched.Elem().Interface())
+			}
+		})
+	}
+}
+
+func TestSliceKeySubset(t *testing.T) {
+	c := comparer.New(comparer.Subset(), comparer.SliceKeyField("ID"))
+	expected := []es14{{ID: 2, Name: "b"}}
+	actual := []es14{{1, "a"}, {2, "b"}, {3, "c"}}
+	if !c.Equal(expected, actual) {
+		t.Errorf("The matched elements should be contained")
+	}
+	if c.Equal([]es14{{ID: 4}}, actual) {
+		t.Errorf("The missing keys should not be contained")
+	}
+}
@@ -0,0 +1,110 @@
+package comparer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrLimitExceeded is the reason of an IncomparableError when the comparison exceeds the MaxDepth or MaxNodes configurations.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// limits holds the resources used by a traversal, shared with its probes and parallel workers.
+type limits struct {
+	ctx   context.Context
+	nodes int64
+}
+
+// MaxDepth returns a new Config that stops the comparisons of the values nested more than depth fields, elements and map entries.
+//
+// When the limit is exceeded, Equal returns false, Compare returns that the values are not comparable and Diff returns the differences found until then.
+// The CompareE, EqualE, CompareContext and EqualContext methods return an *IncomparableError with the ErrLimitExceeded reason.
+func MaxDepth(depth int) Config {
+	return func(comp *Comparer) {
+		comp.maxDepth = depth
+	}
+}
+
+// MaxNodes returns a new Config that stops the comparisons that visit more than nodes values, following the rules of the MaxDepth configuration.
+// The pointers and interfaces count as visited values.
+func MaxNodes(nodes int) Config {
+	return func(comp *Comparer) {
+		comp.maxNodes = nodes
+	}
+}
+
+// CompareContext is like CompareE, but it stops when the context ctx is done, returning an *IncomparableError with the error of the context as its reason.
+func (c *Comparer) CompareContext(ctx context.Context, a interface{}, b interface{}) (int, error) {
+	n := c.root(CompareOperation, a, b)
+	n.state = &state{guard: true}
+	return c.compareChecked(n, ctx)
+}
+
+// EqualContext is like EqualE, but it stops when the context ctx is done, returning an *IncomparableError with the error of the context as its reason.
+func (c *Comparer) EqualContext(ctx context.Context, a interface{}, b interface{}) (bool, error) {
+	n := c.root(EqualOperation, a, b)
+	n.state = &state{guard: true}
+	return c.equalRoot(n, ctx)
+}
+
+// equalRoot reports whether the values of the root node n are equal, and returns the error that stopped the traversal, if any.
+func (c *Comparer) equalRoot(n *Node, ctx context.Context) (equal bool, err error) {
+	c.limit(n, ctx)
+	defer recoverError(&err)
+	return c.equal(n), nil
+}
+
+// compareRoot compares the values of the root node n, and returns the error that stopped the traversal, if any.
+func (c *Comparer) compareRoot(n *Node, ctx context.Context) (comparison int, comparable bool, err error) {
+	c.limit(n, ctx)
+	defer recoverError(&err)
+	comparison, comparable = c.compare(n)
+	return comparison, comparable, nil
+}
+
+// compareChecked compares the values of the root node n, and returns an *IncomparableError when they are not comparable.
+func (c *Comparer) compareChecked(n *Node, ctx context.Context) (int, error) {
+	comparison, comparable, err := c.compareRoot(n, ctx)
+	if err != nil {
+		return 0, err
+	} else if !comparable {
+		if n.state.err == nil {
+			return 0, n.incomparable(ErrUnsupportedKind, nil)
+		}
+		return 0, n.state.err
+	}
+	return comparison, nil
+}
+
+// limit sets the limits of the traversal that starts in the root node n, if there is any.
+func (c *Comparer) limit(n *Node, ctx context.Context) {
+	if c.maxDepth <= 0 && c.maxNodes <= 0 && ctx == nil {
+		return
+	}
+	if n.state == nil {
+		n.state = &state{}
+	}
+	n.state.limits = &limits{ctx: ctx}
+}
+
+// check stops the traversal when the node exceeds the limits.
+func (n *Node) check() {
+	l := n.state.limits
+	nodes := atomic.AddInt64(&l.nodes, 1)
+	if max := n.comparer.maxNodes; max > 0 && nodes > int64(max) {
+		panic(n.incomparable(ErrLimitExceeded, nil))
+	} else if max := n.comparer.maxDepth; max > 0 && n.Depth > max {
+		panic(n.incomparable(ErrLimitExceeded, nil))
+	}
+	if l.ctx != nil {
+		select {
+		case <-l.ctx.Done():
+			panic(n.incomparable(l.ctx.Err(), nil))
+		default:
+		}
+	}
+}
+
+func (n *Node) limited() bool {
+	return n.state != nil && n.state.limits != nil
+}
@@ -0,0 +1,107 @@
+package comparer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es16 struct {
+	Value int
+	Next  *es16
+}
+
+func chain(length int, last int) *es16 {
+	var head *es16
+	for i := 0; i < length; i++ {
+		head = &es16{Value: i, Next: head}
+	}
+	head.Value = last
+	return head
+}
+
+func TestLimits(t *testing.T) {
+	cases := map[string]struct {
+		config   comparer.Config
+		a        interface{}
+		b        interface{}
+		exceeded bool
+	}{
+		"NoLimit": {
+			comparer.MaxDepth(0),
+			chain(100, 1),
+			chain(100, 1),
+			false,
+		},
+		"DepthInside": {
+			comparer.MaxDepth(20),
+			chain(10, 1),
+			chain(10, 1),
+			false,
+		},
+		"DepthExceeded": {
+			comparer.MaxDepth(20),
+			chain(100, 1),
+			chain(100, 1),
+			true,
+		},
+		"NodesInside": {
+			comparer.MaxNodes(100),
+			[]int{1, 2, 3},
+			[]int{1, 2, 3},
+			false,
+		},
+		"NodesExceeded": {
+			comparer.MaxNodes(100),
+			make([]int, 1000),
+			make([]int, 1000),
+			true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := comparer.New(tc.config)
+			if c.Equal(tc.a, tc.b) == tc.exceeded {
+				t.Errorf("The values should be equal only inside the limits")
+			}
+			_, err := c.EqualE(tc.a, tc.b)
+			if errors.Is(err, comparer.ErrLimitExceeded) != tc.exceeded {
+				t.Errorf("The limit error should be %v, got %v", tc.exceeded, err)
+			}
+		})
+	}
+}
+
+func TestLimitsDiff(t *testing.T) {
+	c := comparer.New(comparer.MaxNodes(5))
+	diffs := c.Diff([]int{1, 2, 3, 4, 5, 6}, []int{0, 2, 0, 4, 0, 6})
+	if len(diffs) != 2 {
+		t.Errorf("The differences should be the ones found inside the limit, got %+v", diffs)
+	}
+}
+
+func TestContext(t *testing.T) {
+	c := comparer.New()
+	a, b := make([]int, 1000), make([]int, 1000)
+
+	if equal, err := c.EqualContext(context.Background(), a, b); !equal || err != nil {
+		t.Errorf("The values should be equal, got %v and %v", equal, err)
+	}
+	if comparison, err := c.CompareContext(context.Background(), 1, 2); comparison != -1 || err != nil {
+		t.Errorf("The comparison should be -1, got %d and %v", comparison, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.EqualContext(ctx, a, b)
+	var ie *comparer.IncomparableError
+	if !errors.As(err, &ie) || !errors.Is(err, context.Canceled) {
+		t.Errorf("The comparison should be canceled, got %v", err)
+	}
+	if _, err := c.CompareContext(ctx, 1, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("The comparison should be canceled, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package comparer
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// A Matcher is a value that can be placed inside the first value of Equal, instead of a value that it matches.
+// When the Matcher is found, it is called with the value of the same path in the second value, instead of comparing them.
+//
+// The Matchers returned by this package, like Any, Between or MatcherFunc, can be placed in the interface fields, elements and map values.
+// The other types that implement Matcher are compared as any other value, so the Matchers of other packages need the PathMatcher configuration,
+// that places a Matcher in a path.
+//
+// The Matchers are only found in the first value, so the comparisons with Matchers are not symmetric: Equal(Any(), 5) is true,
+// but Equal(5, Any()) is false.
+type Matcher interface {
+	// Match reports whether the value v matches. The value v is nil when it does not exist.
+	Match(v interface{}) bool
+	// String returns a description of the Matcher, like "Between(1, 10)".
+	String() string
+}
+
+type matcher struct {
+	name  string
+	match func(v interface{}) bool
+}
+
+var matcherType = reflect.TypeOf((*matcher)(nil))
+
+// MatcherFunc returns a Matcher that matches the values for which match returns true, described by name, like "Even()".
+func MatcherFunc(name string, match func(v interface{}) bool) Matcher {
+	return &matcher{name, match}
+}
+
+// PathMatcher returns a new Config that uses the Matcher m for the values in the path, instead of the value in the first value.
+func PathMatcher(path string, m Matcher) Config {
+	return func(comp *Comparer) {
+		if comp.matchers == nil {
+			comp.matchers = map[string]Matcher{}
+		}
+		comp.matchers[path] = m
+	}
+}
+
+// Any returns a Matcher that matches any value, including nil.
+func Any() Matcher {
+	return &matcher{"Any()", func(v interface{}) bool {
+		return true
+	}}
+}
+
+// NotZero returns a Matcher that matches any value that is not nil nor the zero value of its type.
+func NotZero() Matcher {
+	return &matcher{"NotZero()", func(v interface{}) bool {
+		return v != nil && !reflect.ValueOf(v).IsZero()
+	}}
+}
+
+// Regex returns a Matcher that matches the strings and byte slices that match the regular expression pattern.
+// It panics if the pattern can not be parsed.
+func Regex(pattern string) Matcher {
+	r := regexp.MustCompile(pattern)
+	return &matcher{fmt.Sprintf("Regex(%q)", pattern), func(v interface{}) bool {
+		rv := reflect.ValueOf(v)
+		if !rv.IsValid() {
+			return false
+		} else if rv.Kind() == reflect.String {
+			return r.MatchString(rv.String())
+		} else if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return r.Match(rv.Bytes())
+		}
+		return false
+	}}
+}
+
+// Between returns a Matcher that matches the values between min and max, both included.
+// The numbers are compared by their value, whatever their types, so Between(1, 10) matches int64(5) and 5.5.
+// The other values are compared with a Comparer with the standard library comparators.
+func Between(min interface{}, max interface{}) Matcher {
+	c := New(StdlibComparators())
+	return &matcher{fmt.Sprintf("Between(%v, %v)", min, max), func(v interface{}) bool {
+		if x, ok := number(v); ok {
+			low, lok := number(min)
+			high, hok := number(max)
+			return lok && hok && low.Cmp(x) <= 0 && x.Cmp(high) <= 0
+		}
+		if comparison, comparable := c.Compare(min, v); !comparable || comparison > 0 {
+			return false
+		}
+		if comparison, comparable := c.Compare(v, max); !comparable || comparison > 0 {
+			return false
+		}
+		return true
+	}}
+}
+
+// number returns the value v as a big.Float when it has a numeric kind, so the numbers of different types can be compared exactly.
+// The NaN values are not numbers.
+func number(v interface{}) (*big.Float, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(rv.Float()) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(rv.Float()), true
+	}
+	return nil, false
+}
+
+// Match reports whether the value v matches.
+func (m *matcher) Match(v interface{}) bool {
+	return m.match(v)
+}
+
+// String returns the description of the Matcher.
+func (m *matcher) String() string {
+	return m.name
+}
+
+// matcher returns the Matcher of the node, either from the first value or from the configuration.
+func (c *Comparer) matcher(n *Node) (Matcher, bool) {
+	if m, ok := c.matchers[n.Path]; ok {
+		return m, true
+	} else if len(c.matchers) > 0 && strings.ContainsRune(n.Path, '{') {
+		if m, ok := c.matchers[plain(n.Path)]; ok {
+			return m, true
+		}
+	}
+	if n.A.IsValid() && n.A.Type() == matcherType {
+		return n.A.Interface().(Matcher), true
+	}
+	return nil, false
+}
@@ -0,0 +1,114 @@
+package comparer_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es10 struct {
+	ID      interface{}
+	Name    string
+	Count   interface{}
+	Created time.Time
+}
+
+func TestMatcher(t *testing.T) {
+	now := time.Now()
+
+	cases := map[string]struct {
+		matcher comparer.Matcher
+		value   interface{}
+		match   bool
+	}{
+		"Any":             {comparer.Any(), 5, true},
+		"Func":            {comparer.MatcherFunc("Even()", func(v interface{}) bool { return v.(int)%2 == 0 }), 4, true},
+		"AnyNil":          {comparer.Any(), nil, true},
+		"NotZero":         {comparer.NotZero(), 5, true},
+		"NotZeroZero":     {comparer.NotZero(), 0, false},
+		"NotZeroNil":      {comparer.NotZero(), nil, false},
+		"Regex":           {comparer.Regex("^usr_"), "usr_1", true},
+		"RegexBytes":      {comparer.Regex("^usr_"), []byte("usr_1"), true},
+		"RegexDifferent":  {comparer.Regex("^usr_"), "grp_1", false},
+		"RegexNumber":     {comparer.Regex("^1"), 1, false},
+		"Between":         {comparer.Between(1, 10), 10, true},
+		"BetweenLess":     {comparer.Between(1, 10), 0, false},
+		"BetweenGreater":  {comparer.Between(1, 10), 11, false},
+		"BetweenType":     {comparer.Between(1, 10), "5", false},
+		"BetweenInt64":    {comparer.Between(1, 10), int64(5), true},
+		"BetweenFloat":    {comparer.Between(1, 10), 5.5, true},
+		"BetweenFloatOut": {comparer.Between(1, 10), 10.5, false},
+		"BetweenUint":     {comparer.Between(int64(-1), 1.5), uint8(1), true},
+		"BetweenNaN":      {comparer.Between(1, 10), math.NaN(), false},
+		"BetweenTime":     {comparer.Between(now.Add(-time.Hour), now.Add(time.Hour)), now, true},
+		"BetweenTimeLate": {comparer.Between(now.Add(-time.Hour), now), now.Add(time.Minute), false},
+	}
+
+	c := comparer.New()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if c.Equal(tc.matcher, tc.value) != tc.match {
+				if tc.match {
+					t.Errorf("The matcher %s should match the value %+v", tc.matcher, tc.value)
+				} else {
+					t.Errorf("The matcher %s should not match the value %+v", tc.matcher, tc.value)
+				}
+			}
+		})
+	}
+}
+
+type even struct{}
+
+func (even) Match(v interface{}) bool {
+	n, ok := v.(int)
+	return ok && n%2 == 0
+}
+
+func (even) String() string {
+	return "even"
+}
+
+func TestMatcherDetection(t *testing.T) {
+	c := comparer.New()
+	if c.Equal(es10{ID: even{}}, es10{ID: 4}) || !c.Equal(es10{ID: even{}}, es10{ID: even{}}) {
+		t.Errorf("The values of other types that implement Matcher should be compared as values")
+	}
+	if !comparer.New(comparer.PathMatcher("ID", even{})).Equal(es10{}, es10{ID: 4}) {
+		t.Errorf("The Matchers of other types should be placed with PathMatcher")
+	}
+	if !c.Equal(comparer.Any(), 5) || c.Equal(5, comparer.Any()) {
+		t.Errorf("The Matchers should only be found in the first value")
+	}
+}
+
+func TestMatcherNested(t *testing.T) {
+	c := comparer.New(comparer.PathMatcher("[0].Created", comparer.NotZero()))
+
+	expected := []es10{{ID: comparer.Regex("^usr_"), Name: "a", Count: comparer.Between(1, 3)}}
+	actual := []es10{{ID: "usr_1", Name: "a", Count: 2, Created: time.Now()}}
+	if !c.Equal(expected, actual) {
+		t.Errorf("The values should be equal")
+	}
+
+	actual = []es10{{ID: "grp_1", Name: "a", Count: 4}}
+	diffs := c.Diff(expected, actual)
+	paths := []string{}
+	for _, d := range diffs {
+		paths = append(paths, d.Path)
+	}
+	if !reflect.DeepEqual(paths, []string{"[0].ID", "[0].Count", "[0].Created"}) {
+		t.Errorf("The differences should be in [0].ID, [0].Count and [0].Created, got %v", paths)
+	}
+	if diffs[0].A.(comparer.Matcher).String() != `Regex("^usr_")` {
+		t.Errorf("The difference should hold the matcher, got %v", diffs[0].A)
+	}
+
+	m := map[string]interface{}{"id": comparer.Any(), "name": "a"}
+	if !c.Equal(m, map[string]interface{}{"id": 7, "name": "a"}) {
+		t.Errorf("The values should be equal")
+	}
+}
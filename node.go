@@ -0,0 +1,222 @@
+package comparer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// An Operation identifies the Comparer method that started a comparison.
+type Operation int
+
+const (
+	// EqualOperation is the operation started by the Equal method.
+	EqualOperation Operation = iota
+	// CompareOperation is the operation started by the Compare method.
+	CompareOperation
+)
+
+// A Node describes the position of two values inside the values of a comparison.
+type Node struct {
+	// Path is the same path received by the Comparator, like "A.B[0]".
+	Path string
+	// Depth is the number of fields, elements and map entries between the root values and the node values.
+	// The pointers and interfaces are followed without increasing the depth.
+	Depth int
+	// Operation is the Comparer method that started the comparison.
+	Operation Operation
+	// A and B are the values of the node. They can be invalid when a pointer or interface is nil.
+	A, B reflect.Value
+	// Parent is the node of the enclosing struct, array, slice or map, or nil for the root values.
+	// For the transformed values, it is the node of the values before the transformation.
+	Parent *Node
+	// Field is the struct field of the values, or nil when the values are not struct fields.
+	// It is only set when the Comparer has NodeComparators or when the differences are collected or reported.
+	Field *reflect.StructField
+
+	comparer *Comparer
+	state    *state
+	step     *Step
+	next     int
+	// transformer is the position plus one of the transformer that produced the values, or 0.
+	transformer int
+	entry       bool
+	indirect    bool
+	// delegated is set when Next or Descend found different values, that are already reported.
+	delegated bool
+}
+
+// A StepKind is the kind of a Step.
+type StepKind int
+
+const (
+	// FieldStep is the step to a struct field.
+	FieldStep StepKind = iota
+	// IndexStep is the step to an array or slice element.
+	IndexStep
+	// KeyStep is the step to a map entry.
+	KeyStep
+	// TransformStep is the step to the values returned by a transformer.
+	TransformStep
+	// CustomStep is the step passed to the Descend method.
+	CustomStep
+)
+
+// A Step is an element of the path of a node, from its parent to the node.
+type Step struct {
+	// Kind is the kind of the step.
+	Kind StepKind
+	// Name is the name of the struct field, the name of the transformer, the step passed to Descend, or the key of an element matched by SliceKey, like "ID=42".
+	Name string
+	// Field is the struct field of a FieldStep.
+	Field *reflect.StructField
+	// Index is the index of an IndexStep.
+	Index int
+	// Key is the map key of a KeyStep, or the key of an element matched by SliceKey.
+	Key interface{}
+
+	// bytes is set for the IndexStep of a byte slice, that is encoded as a base64 string in JSON.
+	bytes bool
+}
+
+// A NodeComparator is like a Comparator, but it also receives the Node of the compared values.
+type NodeComparator func(n *Node, a interface{}, b interface{}) (int, bool)
+
+// CustomNodeComparator returns a new Config that adds a NodeComparator.
+// The NodeComparators are called in the order they were added, after the Comparator function, until one of them compares the values.
+func CustomNodeComparator(c NodeComparator) Config {
+	return func(comp *Comparer) {
+		comp.nodes = append(comp.nodes, c)
+	}
+}
+
+// Next compares a and b in the position of the node, using the NodeComparators that follow the one being called, the type comparators and the default rules.
+// It allows a NodeComparator to delegate to the default behavior, or to adjust its result. The differences found by Next are collected and
+// reported as if the NodeComparator was not called, and the node does not report them again when the NodeComparator returns them.
+//
+// The result follows the operation of the node. In the EqualOperation, the result is 0 if the values are equal and 1 otherwise.
+func (n *Node) Next(a interface{}, b interface{}) (int, bool) {
+	// The values are in the position of the node, so they are not visited again.
+	m := *n
+	m.A, m.B = reflect.ValueOf(a), reflect.ValueOf(b)
+	m.indirect, m.delegated = true, false
+	return n.delegate(n.comparer.result(&m))
+}
+
+// Descend compares a and b as a child of the node, using the whole configuration of the Comparer.
+// The step is appended to the path of the node, like ".ID" or "[0]".
+//
+// The result follows the operation of the node. In the EqualOperation, the result is 0 if the values are equal and 1 otherwise.
+func (n *Node) Descend(step string, a interface{}, b interface{}) (int, bool) {
+	path := n.Path + step
+	if n.Path == "" {
+		path = strings.TrimPrefix(step, ".")
+	}
+	c := n.child(path, reflect.ValueOf(a), reflect.ValueOf(b))
+	c.step = &Step{Kind: CustomStep, Name: step}
+	return n.delegate(n.comparer.result(c))
+}
+
+// delegate records the result of a comparison made by Next or Descend, so the differences it reported are not reported again by the node.
+func (n *Node) delegate(comparison int, comparable bool) (int, bool) {
+	if n.Operation == EqualOperation && comparison != 0 {
+		n.delegated = true
+	}
+	return comparison, comparable
+}
+
+// Steps returns the steps from the root values to the node.
+// Like Field, the steps are only recorded when the Comparer has NodeComparators or when the differences are collected or reported.
+func (n *Node) Steps() []Step {
+	var steps []Step
+	for m := n; m != nil; m = m.Parent {
+		if m.step != nil {
+			steps = append(steps, *m.step)
+		}
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
+
+func (c *Comparer) root(op Operation, a interface{}, b interface{}) *Node {
+	return &Node{Operation: op, A: reflect.ValueOf(a), B: reflect.ValueOf(b), comparer: c}
+}
+
+func (n *Node) child(path string, a reflect.Value, b reflect.Value) *Node {
+	return &Node{Path: path, Depth: n.Depth + 1, Operation: n.Operation, A: a, B: b, Parent: n, comparer: n.comparer, state: n.state}
+}
+
+// elem returns a node with the same position for the values referenced by the node values.
+func (n *Node) elem(a reflect.Value, b reflect.Value) *Node {
+	e := *n
+	e.A, e.B = a, b
+	e.next = 0
+	e.entry = false
+	e.indirect = true
+	e.delegated = false
+	return &e
+}
+
+// index returns the node of the elements with index i. The values are invalid when i is out of range.
+func (n *Node) index(i int) *Node {
+	var a, b reflect.Value
+	if i < n.A.Len() {
+		a = n.A.Index(i)
+	}
+	if i < n.B.Len() {
+		b = n.B.Index(i)
+	}
+	c := n.indexChild(i, a, b)
+	c.entry = true
+	return c
+}
+
+// indexChild returns the child node of the values a and b, as the elements with index i.
+func (n *Node) indexChild(i int, a reflect.Value, b reflect.Value) *Node {
+	c := n.child(n.indexPath(i), a, b)
+	if n.detailed() {
+		t := n.A.Type()
+		if !n.A.IsValid() {
+			t = n.B.Type()
+		}
+		c.step = &Step{Kind: IndexStep, Index: i, bytes: t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8}
+	}
+	return c
+}
+
+func (n *Node) indexPath(i int) string {
+	return n.Path + "[" + fmt.Sprintf("%d", i) + "]"
+}
+
+func (n *Node) key(k reflect.Value) *Node {
+	c := n.child(n.Path+"["+fmt.Sprintf("%v", k.Interface())+"]", n.A.MapIndex(k), n.B.MapIndex(k))
+	if n.detailed() {
+		c.step = &Step{Kind: KeyStep, Key: k.Interface()}
+	}
+	c.entry = true
+	return c
+}
+
+func (n *Node) field(i int) *Node {
+	path := n.Path
+	if path != "" {
+		path += "."
+	}
+	path += n.A.Type().Field(i).Name
+
+	c := n.child(path, n.A.Field(i), n.B.Field(i))
+	if n.detailed() {
+		f := n.A.Type().Field(i)
+		c.Field = &f
+		c.step = &Step{Kind: FieldStep, Name: f.Name, Field: &f}
+	}
+	return c
+}
+
+// detailed reports whether the nodes need their Field and their steps, because a NodeComparator can read them
+// or the traversal collects or reports the differences.
+func (n *Node) detailed() bool {
+	return len(n.comparer.nodes) > 0 || (n.state != nil && (n.state.report || n.state.reporter != nil))
+}
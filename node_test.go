@@ -0,0 +1,249 @@
+package comparer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es6 struct {
+	A string `comparer:"fold"`
+	B []es1
+	C *es1
+}
+
+func TestNodeComparator(t *testing.T) {
+	nodes := map[string]comparer.Node{}
+	comparator := func(n *comparer.Node, a interface{}, b interface{}) (int, bool) {
+		nodes[n.Path] = *n
+		if n.Field != nil && n.Field.Tag.Get("comparer") == "fold" {
+			return strings.Compare(strings.ToUpper(a.(string)), strings.ToUpper(b.(string))), true
+		}
+		return 0, false
+	}
+	c := comparer.New(comparer.CustomNodeComparator(comparator))
+
+	a := es6{"test", []es1{{1, "test1"}}, &es1{2, "test2"}}
+	b := es6{"TEST", []es1{{1, "test1"}}, &es1{2, "test2"}}
+	if !c.Equal(a, b) {
+		t.Errorf("The values should be equal")
+	}
+
+	run := func(t *testing.T, path string, depth int, parent string, field string) {
+		n, ok := nodes[path]
+		if !ok {
+			t.Fatalf("The node should be visited")
+		}
+		if n.Depth != depth {
+			t.Errorf("The depth should be %d, got %d", depth, n.Depth)
+		}
+		if n.Operation != comparer.EqualOperation {
+			t.Errorf("The operation should be EqualOperation")
+		}
+		if n.Parent == nil {
+			if parent != "-" {
+				t.Errorf("The parent should be %q", parent)
+			}
+		} else if n.Parent.Path != parent {
+			t.Errorf("The parent should be %q, got %q", parent, n.Parent.Path)
+		}
+		if n.Field == nil {
+			if field != "" {
+				t.Errorf("The field should be %q", field)
+			}
+		} else if n.Field.Name != field {
+			t.Errorf("The field should be %q, got %q", field, n.Field.Name)
+		}
+	}
+
+	t.Run("Root", func(t *testing.T) { run(t, "", 0, "-", "") })
+	t.Run("Field", func(t *testing.T) { run(t, "A", 1, "", "A") })
+	t.Run("Element", func(t *testing.T) { run(t, "B[0]", 2, "B", "") })
+	t.Run("ElementField", func(t *testing.T) { run(t, "B[0].B", 3, "B[0]", "B") })
+	t.Run("Pointer", func(t *testing.T) { run(t, "C.A", 2, "C", "A") })
+
+	if nodes["B[0]"].Parent.A.Type() != reflect.TypeOf([]es1{}) {
+		t.Errorf("The parent values should be the slices")
+	}
+}
+
+func TestNodeComparatorOperation(t *testing.T) {
+	var operation comparer.Operation
+	c := comparer.New(comparer.CustomNodeComparator(func(n *comparer.Node, a interface{}, b interface{}) (int, bool) {
+		operation = n.Operation
+		return 0, false
+	}))
+
+	c.Compare(1, 2)
+	if operation != comparer.CompareOperation {
+		t.Errorf("The operation should be CompareOperation")
+	}
+	c.Equal(1, 2)
+	if operation != comparer.EqualOperation {
+		t.Errorf("The operation should be EqualOperation")
+	}
+}
+
+func TestNodeComparatorOrder(t *testing.T) {
+	var calls []string
+	c := comparer.New(
+		comparer.CustomComparator(func(_ string, a interface{}, b interface{}) (int, bool) {
+			calls = append(calls, "comparator")
+			return 0, false
+		}),
+		comparer.CustomNodeComparator(func(n *comparer.Node, a interface{}, b interface{}) (int, bool) {
+			calls = append(calls, "first")
+			return 0, true
+		}),
+		comparer.CustomNodeComparator(func(n *comparer.Node, a interface{}, b interface{}) (int, bool) {
+			calls = append(calls, "second")
+			return 0, true
+		}),
+	)
+
+	if comparison, comparable := c.Compare(1, 2); !comparable || comparison != 0 {
+		t.Errorf("The values should be equal")
+	}
+	if strings.Join(calls, ",") != "comparator,first" {
+		t.Errorf("The calls should be comparator,first, got %s", strings.Join(calls, ","))
+	}
+}
+
+func TestNodeNext(t *testing.T) {
+	reverse := func(n *comparer.Node, a interface{}, b interface{}) (int, bool) {
+		if _, ok := a.(int); !ok {
+			return 0, false
+		}
+		comparison, comparable := n.Next(a, b)
+		return -comparison, comparable
+	}
+	c := comparer.New(comparer.CustomNodeComparator(reverse))
+
+	if comparison, comparable := c.Compare(1, 2); !comparable || comparison != 1 {
+		t.Errorf("The value 1 should be greater than the value 2")
+	}
+	if !c.Equal([]int{1, 2}, []int{1, 2}) {
+		t.Errorf("The values should be equal")
+	}
+	if c.Equal([]int{1, 2}, []int{1, 3}) {
+		t.Errorf("The values should not be equal")
+	}
+}
+
+func TestNodeNextChain(t *testing.T) {
+	upper := func(n *comparer.Node, a interface{}, b interface{}) (int, bool) {
+		sa, ok := a.(string)
+		if !ok {
+			return 0, false
+		}
+		return n.Next(strings.ToUpper(sa), strings.ToUpper(b.(string)))
+	}
+	trim := func(n *comparer.Node, a interface{}, b interface{}) (int, bool) {
+		sa, ok := a.(string)
+		if !ok {
+			return 0, false
+		}
+		return n.Next(strings.TrimSpace(sa), strings.TrimSpace(b.(string)))
+	}
+	c := comparer.New(comparer.CustomNodeComparator(upper), comparer.CustomNodeComparator(trim))
+
+	if !c.Equal(es1{1, " test "}, es1{1, "TEST"}) {
+		t.Errorf("The values should be equal")
+	}
+	if c.Equal(es1{1, " test "}, es1{1, "TEST2"}) {
+		t.Errorf("The values should not be equal")
+	}
+}
+
+func TestNodeDescend(t *testing.T) {
+	var paths []string
+	byA := func(n *comparer.Node, a interface{}, b interface{}) (int, bool) {
+		paths = append(paths, n.Path)
+		if ea, ok := a.(es1); ok {
+			return n.Descend(".A", ea.A, b.(es1).A)
+		}
+		return 0, false
+	}
+	c := comparer.New(comparer.CustomNodeComparator(byA))
+
+	if !c.Equal([]es1{{1, "test1"}}, []es1{{1, "test2"}}) {
+		t.Errorf("The values should be equal")
+	}
+	if c.Equal([]es1{{1, "test1"}}, []es1{{2, "test1"}}) {
+		t.Errorf("The values should not be equal")
+	}
+	if comparison, comparable := c.Compare(es1{1, "test2"}, es1{2, "test1"}); !comparable || comparison != -1 {
+		t.Errorf("The value %+v shoud be greater than the value %+v", es1{2, "test1"}, es1{1, "test2"})
+	}
+	if strings.Join(paths[:3], ",") != ",[0],[0].A" {
+		t.Errorf("The paths should be ,[0],[0].A, got %s", strings.Join(paths[:3], ","))
+	}
+}
+
+func TestNodeNextPassThrough(t *testing.T) {
+	cases := map[string]struct {
+		a interface{}
+		b interface{}
+	}{
+		"Field":  {es1{1, "a"}, es1{1, "b"}},
+		"Slice":  {[]es1{{1, "a"}, {2, "b"}}, []es1{{1, "a"}, {3, "c"}, {4, "d"}}},
+		"Map":    {map[string]es1{"a": {1, "a"}}, map[string]es1{"a": {1, "b"}, "b": {2, "b"}}},
+		"Equal":  {es1{1, "a"}, es1{1, "a"}},
+		"String": {"a\nb", "a\nc"},
+	}
+
+	plain := comparer.New()
+	through := comparer.New(comparer.CustomNodeComparator(func(n *comparer.Node, a, b interface{}) (int, bool) {
+		return n.Next(a, b)
+	}))
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if expected, actual := summary(plain.Diff(tc.a, tc.b)), summary(through.Diff(tc.a, tc.b)); !reflect.DeepEqual(expected, actual) {
+				t.Errorf("The differences should be %+v, got %+v", expected, actual)
+			}
+			if expected, actual := plain.Stats(tc.a, tc.b), through.Stats(tc.a, tc.b); !reflect.DeepEqual(expected, actual) {
+				t.Errorf("The statistics should be %+v, got %+v", expected, actual)
+			}
+			expected, actual := &comparer.TextReporter{}, &comparer.TextReporter{}
+			plain.Report(tc.a, tc.b, expected)
+			through.Report(tc.a, tc.b, actual)
+			if expected.String() != actual.String() {
+				t.Errorf("The report should be:\n%s\ngot:\n%s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestNodeStepsRecorded(t *testing.T) {
+	var steps []comparer.Step
+	c := comparer.New(comparer.CustomNodeComparator(func(n *comparer.Node, a, b interface{}) (int, bool) {
+		if n.Path == "[0].A" {
+			steps = n.Steps()
+		}
+		return 0, false
+	}))
+	c.Equal([]es1{{1, "a"}}, []es1{{1, "a"}})
+	if len(steps) != 2 || steps[0].Kind != comparer.IndexStep || steps[1].Field == nil || steps[1].Field.Name != "A" {
+		t.Errorf("The steps should be recorded for the NodeComparators, got %+v", steps)
+	}
+
+	diffs := comparer.New().Diff([]es1{{1, "a"}}, []es1{{1, "b"}})
+	if len(diffs) != 1 || len(diffs[0].Steps) != 2 || diffs[0].Steps[1].Name != "B" {
+		t.Errorf("The steps should be recorded for the differences, got %+v", diffs)
+	}
+}
+
+func BenchmarkEqualStructs(b *testing.B) {
+	x, y := make([]es1, 100000), make([]es1, 100000)
+	for i := range x {
+		x[i], y[i] = es1{i, "a"}, es1{i, "a"}
+	}
+	c := comparer.New()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Equal(x, y)
+	}
+}
@@ -0,0 +1,114 @@
+package comparer
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Parallel returns a new Config that compares the elements of the arrays, slices and maps with at least threshold elements in parallel,
+// splitting them across the number of workers. If workers is 0, it uses the value of runtime.GOMAXPROCS.
+//
+// The workers are shared by the nested arrays, slices and maps, so a comparison runs at most workers goroutines, including the calling one.
+// When all of them are busy, the elements are compared by the goroutine that finds them.
+//
+// The workers stop as soon as any of them finds a difference. The parallel comparison only applies to the comparisons that stop at the first difference,
+// so the results of Diff, Stats and Report are the same as without this configuration. The Comparators and the other functions of the configuration
+// must be safe for concurrent use.
+func Parallel(workers int, threshold int) Config {
+	return func(comp *Comparer) {
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		comp.workers = workers
+		comp.threshold = threshold
+	}
+}
+
+// each compares the children returned by child for the indexes from 0 to length, in order or in parallel. The child can be nil to skip an index.
+func (c *Comparer) each(n *Node, length int, child func(i int) *Node) bool {
+	if c.workers > 1 && length >= c.threshold && length > 1 && !n.exhaustive() && (n.state == nil || (!n.state.report && n.state.reporter == nil)) {
+		return c.parallel(n, length, child)
+	}
+	ok := true
+	for i := 0; i < length && n.proceed(ok); i++ {
+		if m := child(i); m != nil {
+			ok = c.equal(m) && ok
+		}
+	}
+	return ok
+}
+
+// parallel compares the children returned by child for the indexes from 0 to length, splitting them across the workers.
+// The parts that do not find an idle worker are compared by the calling goroutine, after starting the others.
+// The panics of the workers are raised again in the calling goroutine.
+func (c *Comparer) parallel(n *Node, length int, child func(i int) *Node) bool {
+	workers := c.workers
+	if workers > length {
+		workers = length
+	}
+	size := (length + workers - 1) / workers
+	var pool chan struct{}
+	if n.state != nil {
+		pool = n.state.workers
+	}
+	if pool == nil {
+		pool = make(chan struct{}, c.workers-1)
+	}
+
+	var stop int32
+	var failure interface{}
+	var once sync.Once
+	var wg sync.WaitGroup
+	run := func(start int, end int) {
+		defer func() {
+			if r := recover(); r != nil {
+				once.Do(func() { failure = r })
+				atomic.StoreInt32(&stop, 1)
+			}
+		}()
+
+		s := n.probe()
+		s.subset = n.state != nil && n.state.subset
+		s.workers = pool
+		for i := start; i < end && atomic.LoadInt32(&stop) == 0; i++ {
+			m := child(i)
+			if m == nil {
+				continue
+			}
+			m.state = s
+			if !c.equal(m) {
+				atomic.StoreInt32(&stop, 1)
+			}
+		}
+	}
+
+	var rest [][2]int
+	for start := 0; start < length; start += size {
+		end := start + size
+		if end > length {
+			end = length
+		}
+
+		select {
+		case pool <- struct{}{}:
+			wg.Add(1)
+			go func(start int, end int) {
+				defer wg.Done()
+				defer func() { <-pool }()
+				run(start, end)
+			}(start, end)
+		default:
+			rest = append(rest, [2]int{start, end})
+		}
+	}
+	for _, r := range rest {
+		run(r[0], r[1])
+	}
+	wg.Wait()
+
+	if failure != nil {
+		panic(failure)
+	}
+	return stop == 0
+}
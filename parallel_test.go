@@ -0,0 +1,142 @@
+package comparer_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+func large(length int) []es1 {
+	s := make([]es1, length)
+	for i := range s {
+		s[i] = es1{i, fmt.Sprintf("test%d", i)}
+	}
+	return s
+}
+
+func largeMap(length int) map[int]es1 {
+	m := make(map[int]es1, length)
+	for i := 0; i < length; i++ {
+		m[i] = es1{i, fmt.Sprintf("test%d", i)}
+	}
+	return m
+}
+
+func TestParallel(t *testing.T) {
+	changed := large(1000)
+	changed[700].B = "changed"
+	changedMap := largeMap(1000)
+	changedMap[700] = es1{700, "changed"}
+
+	cases := map[string]struct {
+		a        interface{}
+		b        interface{}
+		expected bool
+	}{
+		"EqualSlice":     {large(1000), large(1000), true},
+		"DifferentSlice": {large(1000), changed, false},
+		"EqualMap":       {largeMap(1000), largeMap(1000), true},
+		"DifferentMap":   {largeMap(1000), changedMap, false},
+		"EqualArray":     {[3]int{1, 2, 3}, [3]int{1, 2, 3}, true},
+		"DifferentArray": {[3]int{1, 2, 3}, [3]int{1, 2, 4}, false},
+	}
+
+	sequential := comparer.New()
+	parallel := comparer.New(comparer.Parallel(4, 2))
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if equal := parallel.Equal(tc.a, tc.b); equal != tc.expected {
+				t.Errorf("The result should be %v, got %v", tc.expected, equal)
+			}
+			if !reflect.DeepEqual(summary(parallel.Diff(tc.a, tc.b)), summary(sequential.Diff(tc.a, tc.b))) {
+				t.Errorf("The differences should be the same as the sequential ones")
+			}
+		})
+	}
+}
+
+func TestParallelPanic(t *testing.T) {
+	c := comparer.New(comparer.Parallel(4, 2), comparer.CustomComparator(func(path string, a, b interface{}) (int, bool) {
+		if path == "[500].B" {
+			panic("boom")
+		}
+		return 0, false
+	}))
+	if _, err := c.EqualE(large(1000), large(1000)); !errors.Is(err, comparer.ErrPanic) {
+		t.Errorf("The panic of a worker should be returned, got %v", err)
+	}
+
+	limited := comparer.New(comparer.Parallel(4, 2), comparer.MaxNodes(100))
+	if _, err := limited.EqualE(large(1000), large(1000)); !errors.Is(err, comparer.ErrLimitExceeded) {
+		t.Errorf("The limits should be shared by the workers, got %v", err)
+	}
+}
+
+func TestParallelWorkers(t *testing.T) {
+	var peak int64
+	base := runtime.NumGoroutine()
+	c := comparer.New(comparer.Parallel(4, 2), comparer.CustomComparator(func(path string, a, b interface{}) (int, bool) {
+		for current := int64(runtime.NumGoroutine() - base); ; {
+			last := atomic.LoadInt64(&peak)
+			if current <= last || atomic.CompareAndSwapInt64(&peak, last, current) {
+				break
+			}
+		}
+		return 0, false
+	}))
+
+	nested := make([][][]int, 20)
+	for i := range nested {
+		nested[i] = make([][]int, 20)
+		for j := range nested[i] {
+			nested[i][j] = make([]int, 20)
+		}
+	}
+	if !c.Equal(nested, nested) {
+		t.Fatalf("The values should be equal")
+	}
+	if peak == 0 || peak > 3 {
+		t.Errorf("The nested comparisons should share the workers, got %d more goroutines", peak)
+	}
+}
+
+func BenchmarkEqualSequential(b *testing.B) {
+	c := comparer.New()
+	x, y := large(100000), large(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Equal(x, y)
+	}
+}
+
+func BenchmarkEqualParallel(b *testing.B) {
+	c := comparer.New(comparer.Parallel(0, 1000))
+	x, y := large(100000), large(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Equal(x, y)
+	}
+}
+
+func BenchmarkEqualMapSequential(b *testing.B) {
+	c := comparer.New()
+	x, y := largeMap(100000), largeMap(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Equal(x, y)
+	}
+}
+
+func BenchmarkEqualMapParallel(b *testing.B) {
+	c := comparer.New(comparer.Parallel(0, 1000))
+	x, y := largeMap(100000), largeMap(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Equal(x, y)
+	}
+}
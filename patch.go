@@ -0,0 +1,224 @@
+package comparer
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A Conflict describes a path that is changed in different ways by both sides of a merge.
+type Conflict struct {
+	// Path is the path of the outermost change.
+	Path string
+	// Ours and Theirs are the changes of each side.
+	Ours, Theirs Difference
+}
+
+// Patch changes the value pointed by dst, applying the differences returned by Diff, so it becomes equal to the second value of the comparison.
+// It sets the struct fields and the elements, adds and removes the map entries, and resizes the slices. The nil pointers and maps on the way are allocated.
+// The values of the differences are copied, so dst does not share their slices, maps and pointers.
+//
+// The values referenced by dst are changed in place. It returns an error if dst is not a non-nil pointer, or if a difference can not be applied,
+// like the differences of unexported fields or transformed values. The differences before the failing one remain applied.
+func Patch(dst interface{}, diffs []Difference) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("comparer: the destination of the patch must be a non-nil pointer, got %T", dst)
+	}
+	for _, d := range ordered(diffs) {
+		if err := patch(v.Elem(), d.Steps, d); err != nil {
+			return fmt.Errorf("comparer: can not patch %q: %w", d.Path, err)
+		}
+	}
+	return nil
+}
+
+// patch applies the difference d to the value in the steps from the settable value v.
+func patch(v reflect.Value, steps []Step, d Difference) error {
+	if len(steps) == 0 {
+		if d.Change == Removed {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		return set(v, d.B)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return patch(v.Elem(), steps, d)
+	case reflect.Interface:
+		if v.IsNil() {
+			return fmt.Errorf("the interface is nil")
+		}
+		e := reflect.New(v.Elem().Type()).Elem()
+		e.Set(v.Elem())
+		if err := patch(e, steps, d); err != nil {
+			return err
+		}
+		v.Set(e)
+		return nil
+	}
+
+	s := steps[0]
+	switch s.Kind {
+	case FieldStep:
+		if v.Kind() != reflect.Struct || s.Field == nil {
+			return fmt.Errorf("the field %s is not in a %s", s.Name, v.Type())
+		}
+		f := v.FieldByIndex(s.Field.Index)
+		if !f.CanSet() {
+			return fmt.Errorf("the field %s can not be set", s.Name)
+		}
+		return patch(f, steps[1:], d)
+	case IndexStep:
+		return patchIndex(v, s.Index, steps[1:], d)
+	case KeyStep:
+		return patchKey(v, s.Key, steps[1:], d)
+	default:
+		return fmt.Errorf("the step %q is not part of the value", s.Name)
+	}
+}
+
+func patchIndex(v reflect.Value, i int, steps []Step, d Difference) error {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Errorf("the index %d is not in a %s", i, v.Type())
+	}
+	if len(steps) == 0 && v.Kind() == reflect.Slice && d.Change == Added {
+		if i > v.Len() {
+			return fmt.Errorf("the index %d is out of range", i)
+		}
+		e := reflect.New(v.Type().Elem()).Elem()
+		if err := set(e, d.B); err != nil {
+			return err
+		}
+		s := reflect.Append(v, e)
+		reflect.Copy(s.Slice(i+1, s.Len()), s.Slice(i, s.Len()-1))
+		s.Index(i).Set(e)
+		v.Set(s)
+		return nil
+	}
+	if i >= v.Len() {
+		return fmt.Errorf("the index %d is out of range", i)
+	}
+	if len(steps) == 0 && v.Kind() == reflect.Slice && d.Change == Removed {
+		v.Set(reflect.AppendSlice(v.Slice(0, i), v.Slice(i+1, v.Len())))
+		return nil
+	}
+	return patch(v.Index(i), steps, d)
+}
+
+func patchKey(v reflect.Value, key interface{}, steps []Step, d Difference) error {
+	if v.Kind() != reflect.Map {
+		return fmt.Errorf("the key %v is not in a %s", key, v.Type())
+	}
+	k := reflect.New(v.Type().Key()).Elem()
+	if err := set(k, key); err != nil {
+		return err
+	}
+	if len(steps) == 0 && d.Change == Removed {
+		if !v.IsNil() {
+			v.SetMapIndex(k, reflect.Value{})
+		}
+		return nil
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	// The map values are not addressable, so they are patched in a copy.
+	e := reflect.New(v.Type().Elem()).Elem()
+	if current := v.MapIndex(k); current.IsValid() {
+		e.Set(current)
+	}
+	if err := patch(e, steps, d); err != nil {
+		return err
+	}
+	v.SetMapIndex(k, e)
+	return nil
+}
+
+// set sets the settable value v to a deep copy of x, following the pointers of v when x is not assignable to them.
+// The copy keeps the patched value independent from the values of the differences.
+func set(v reflect.Value, x interface{}) error {
+	if x == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	xv := reflect.ValueOf(Clone(x))
+	switch {
+	case xv.Type().AssignableTo(v.Type()):
+		v.Set(xv)
+	case v.Kind() == reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return set(v.Elem(), x)
+	case xv.Type().ConvertibleTo(v.Type()):
+		v.Set(xv.Convert(v.Type()))
+	default:
+		return fmt.Errorf("a %T can not be set to a %s", x, v.Type())
+	}
+	return nil
+}
+
+// Merge returns the differences that change base into a value with the changes of both ours and theirs, to be applied to a copy of base with Patch.
+// The changes of both sides to the same path, or to a path and one of its descendants, are conflicts, unless they are the same change.
+// The elements added to or removed from the same slice by both sides are conflicts too, because each side moves the indexes of the other.
+// The conflicting changes are not part of the result.
+func (c *Comparer) Merge(base interface{}, ours interface{}, theirs interface{}) ([]Difference, []Conflict) {
+	mine, others := c.Diff(base, ours), c.Diff(base, theirs)
+
+	var merged []Difference
+	var conflicts []Conflict
+	conflicting := make([]bool, len(others))
+	for _, d := range mine {
+		conflict := false
+		for j, e := range others {
+			if !within(d.Steps, e.Steps) && !within(e.Steps, d.Steps) && !(resizes(d) && resizes(e) && within(d.Steps[:len(d.Steps)-1], e.Steps)) {
+				continue
+			} else if d.Path == e.Path && d.Change == e.Change && c.Equal(d.B, e.B) {
+				conflicting[j] = true
+				continue
+			}
+			conflict = true
+			conflicting[j] = true
+			path := d.Path
+			if len(e.Steps) < len(d.Steps) {
+				path = e.Path
+			}
+			conflicts = append(conflicts, Conflict{Path: path, Ours: d, Theirs: e})
+		}
+		if !conflict {
+			merged = append(merged, d)
+		}
+	}
+	for j, e := range others {
+		if !conflicting[j] {
+			merged = append(merged, e)
+		}
+	}
+	return merged, conflicts
+}
+
+// resizes reports whether the difference d adds or removes a slice element.
+func resizes(d Difference) bool {
+	return d.Change != Modified && len(d.Steps) > 0 && d.Steps[len(d.Steps)-1].Kind == IndexStep
+}
+
+// within reports whether the steps p are a prefix of the steps q.
+func within(p []Step, q []Step) bool {
+	if len(p) > len(q) {
+		return false
+	}
+	for i := range p {
+		if !sameStep(p[i], q[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameStep(s Step, t Step) bool {
+	return s.Kind == t.Kind && s.Name == t.Name && s.Index == t.Index && s.Key == t.Key
+}
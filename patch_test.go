@@ -0,0 +1,144 @@
+package comparer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es13 struct {
+	ID     int
+	Name   *string
+	Tags   []string
+	Labels map[string]es1
+	Inner  *es2
+	Any    interface{}
+	hidden int
+}
+
+func TestPatch(t *testing.T) {
+	name := "test"
+	cases := map[string]struct {
+		a interface{}
+		b interface{}
+	}{
+		"Fields": {
+			es13{ID: 1, Inner: &es2{1, "a"}},
+			es13{ID: 2, Name: &name, Inner: &es2{1, "b"}},
+		},
+		"SliceGrow": {
+			es13{Tags: []string{"a"}},
+			es13{Tags: []string{"a", "b", "c"}},
+		},
+		"SliceShrink": {
+			es13{Tags: []string{"a", "b", "c", "d"}},
+			es13{Tags: []string{"x"}},
+		},
+		"Map": {
+			es13{Labels: map[string]es1{"a": {1, "a"}, "b": {2, "b"}}},
+			es13{Labels: map[string]es1{"a": {1, "x"}, "c": {3, "c"}}},
+		},
+		"NilMap": {
+			es13{},
+			es13{Labels: map[string]es1{"a": {1, "a"}}},
+		},
+		"Nil": {
+			es13{Inner: &es2{1, "a"}, Tags: []string{"a"}},
+			es13{},
+		},
+		"Interface": {
+			es13{Any: []interface{}{1, "a"}},
+			es13{Any: []interface{}{2, "a", 3}},
+		},
+	}
+
+	c := comparer.New(comparer.IgnoreUnexported())
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dst := tc.a
+			if err := comparer.Patch(&dst, c.Diff(tc.a, tc.b)); err != nil {
+				t.Fatalf("The patch should be applied, got %v", err)
+			}
+			if diffs := c.Diff(dst, tc.b); len(diffs) > 0 {
+				t.Errorf("The values should be equal after the patch, got %+v", diffs)
+			}
+		})
+	}
+}
+
+func TestPatchErrors(t *testing.T) {
+	c := comparer.New()
+	if err := comparer.Patch(es1{}, c.Diff(es1{1, "a"}, es1{2, "a"})); err == nil {
+		t.Errorf("The patch should fail for a value that is not a pointer")
+	}
+
+	upper := func(v interface{}) interface{} {
+		return strings.ToUpper(v.(string))
+	}
+	a, b := es1{1, "a"}, es1{1, "b"}
+	if err := comparer.Patch(&a, comparer.New(comparer.Transform("upper", nil, upper, "B")).Diff(a, b)); err == nil {
+		t.Errorf("The patch should fail for the transformed values")
+	}
+}
+
+func TestPatchIndependent(t *testing.T) {
+	target := es18{Scores: map[string][]int{"x": {1}}, Child: &es18{Tags: []string{"a"}}}
+	var dst es18
+	if err := comparer.Patch(&dst, comparer.New().Diff(dst, target)); err != nil {
+		t.Fatalf("The differences should be applied, got %v", err)
+	}
+
+	dst.Scores["x"][0] = 99
+	dst.Child.Tags[0] = "b"
+	if target.Scores["x"][0] != 1 || target.Child.Tags[0] != "a" {
+		t.Errorf("The patched value should not share its references with the target, got %+v", target)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	c := comparer.New(comparer.IgnoreUnexported())
+	base := es13{ID: 1, Tags: []string{"a"}, Labels: map[string]es1{"a": {1, "a"}}}
+	ours := es13{ID: 2, Tags: []string{"a"}, Labels: map[string]es1{"a": {1, "b"}}}
+	theirs := es13{ID: 2, Tags: []string{"a", "b"}, Labels: map[string]es1{"a": {1, "c"}}}
+
+	merged, conflicts := c.Merge(base, ours, theirs)
+	if len(conflicts) != 1 || conflicts[0].Path != "Labels[a].B" {
+		t.Fatalf("The merge should have a conflict in Labels[a].B, got %+v", conflicts)
+	}
+	if conflicts[0].Ours.B != "b" || conflicts[0].Theirs.B != "c" {
+		t.Errorf("The conflict should have the changes of both sides, got %+v", conflicts[0])
+	}
+
+	result := base
+	result.Tags = append([]string(nil), base.Tags...)
+	result.Labels = map[string]es1{"a": {1, "a"}}
+	if err := comparer.Patch(&result, merged); err != nil {
+		t.Fatalf("The merge should be applied, got %v", err)
+	}
+	expected := es13{ID: 2, Tags: []string{"a", "b"}, Labels: map[string]es1{"a": {1, "a"}}}
+	if diffs := c.Diff(expected, result); len(diffs) > 0 {
+		t.Errorf("The merged value should have the changes of both sides, got %+v", diffs)
+	}
+
+	_, conflicts = c.Merge(es13{Labels: map[string]es1{"a": {1, "a"}}}, es13{}, es13{Labels: map[string]es1{"a": {2, "a"}}})
+	if len(conflicts) != 1 || conflicts[0].Path != "Labels" {
+		t.Errorf("The change of a map should conflict with the changes of its entries, got %+v", conflicts)
+	}
+
+	base = es13{Tags: []string{"1", "2", "3"}}
+	merged, conflicts = c.Merge(base, es13{Tags: []string{"1", "2"}}, es13{Tags: []string{"1", "2", "3", "4"}})
+	if len(conflicts) != 1 || conflicts[0].Ours.Path != "Tags[2]" || conflicts[0].Theirs.Path != "Tags[3]" {
+		t.Errorf("The elements removed and added by each side should conflict, got %+v", conflicts)
+	}
+	result = es13{Tags: []string{"1", "2", "3"}}
+	if err := comparer.Patch(&result, merged); err != nil || !c.Equal(base, result) {
+		t.Errorf("The merge without the conflicts should be applied, got %v and %+v", err, result)
+	}
+
+	merged, conflicts = c.Merge(base, es13{Tags: []string{"0", "2", "3"}}, es13{Tags: []string{"1", "2"}})
+	result = es13{Tags: []string{"1", "2", "3"}}
+	if err := comparer.Patch(&result, merged); len(conflicts) > 0 || err != nil || !c.Equal(es13{Tags: []string{"0", "2"}}, result) {
+		t.Errorf("The elements modified by one side should merge with the elements removed by the other, got %+v, %v and %+v", conflicts, err, result)
+	}
+}
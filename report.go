@@ -0,0 +1,216 @@
+package comparer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A Reporter receives the nodes visited by the Report method, in depth-first order.
+// The pointers and interfaces are followed without pushing a new node.
+type Reporter interface {
+	// Push is called before comparing the values of the node n.
+	Push(n *Node)
+	// Pop is called after comparing the values of the last pushed node, with the result of the comparison.
+	Pop(equal bool)
+}
+
+// Report reports whether a and b are equal, like Equal, but it visits all the values and sends the nodes to the Reporter r.
+func (c *Comparer) Report(a interface{}, b interface{}, r Reporter) bool {
+	n := c.root(EqualOperation, a, b)
+	n.state = &state{exhaustive: true, reporter: r}
+	equal, _ := c.equalRoot(n, nil)
+	return equal
+}
+
+// A TextReporter is a Reporter that renders the differences as a tree, marking the values of the first value with "-" and the values of the second value with "+".
+// The equal values are elided, and the strings with multiple lines are compared line by line. When they need more than 1000 removed and added
+// lines, like AlignSlices, all their lines are replaced.
+type TextReporter struct {
+	// Format returns the representation of a value. If it is nil, the values are formatted with "%#v", and the Matchers with their String method.
+	Format func(v interface{}) string
+
+	root  *entry
+	stack []*entry
+}
+
+// An entry is a node received by the TextReporter.
+type entry struct {
+	path     string
+	label    string
+	a, b     reflect.Value
+	missing  bool
+	equal    bool
+	children []*entry
+}
+
+// Push adds the node n to the tree.
+func (r *TextReporter) Push(n *Node) {
+	e := &entry{path: n.Path, a: n.A, b: n.B, missing: n.entry}
+	if len(r.stack) == 0 {
+		r.root = e
+	} else {
+		parent := r.stack[len(r.stack)-1]
+		parent.children = append(parent.children, e)
+		e.label = strings.TrimPrefix(strings.TrimPrefix(n.Path, parent.path), ".")
+	}
+	r.stack = append(r.stack, e)
+}
+
+// Pop sets the result of the last pushed node.
+func (r *TextReporter) Pop(equal bool) {
+	r.stack[len(r.stack)-1].equal = equal
+	r.stack = r.stack[:len(r.stack)-1]
+}
+
+// String returns the differences of the reported values, or an empty string if they are equal.
+func (r *TextReporter) String() string {
+	if r.root == nil || r.root.equal {
+		return ""
+	}
+	var sb strings.Builder
+	r.write(&sb, r.root, 0)
+	return sb.String()
+}
+
+func (r *TextReporter) write(sb *strings.Builder, e *entry, depth int) {
+	indent := strings.Repeat("\t", depth)
+	prefix := indent
+	if e.label != "" && depth > 0 {
+		prefix += e.label + ": "
+	}
+	suffix := ""
+	if depth > 0 {
+		suffix = ","
+	}
+
+	if len(e.children) == 0 {
+		if lines, ok := multiline(e.a, e.b); ok {
+			fmt.Fprintf(sb, "  %sstrings.Join({\n", prefix)
+			for _, l := range lines {
+				fmt.Fprintf(sb, "%s %s\t%q,\n", l.mark, indent, l.text)
+			}
+			fmt.Fprintf(sb, "  %s}, \"\\n\")%s\n", indent, suffix)
+			return
+		}
+		if e.a.IsValid() || !e.missing {
+			fmt.Fprintf(sb, "- %s%s%s\n", prefix, r.format(e.a), suffix)
+		}
+		if e.b.IsValid() || !e.missing {
+			fmt.Fprintf(sb, "+ %s%s%s\n", prefix, r.format(e.b), suffix)
+		}
+		return
+	}
+
+	fmt.Fprintf(sb, "  %s%s{\n", prefix, typeName(e.a, e.b))
+	elided := 0
+	flush := func() {
+		if elided > 0 {
+			fmt.Fprintf(sb, "  %s\t... // %d identical %s\n", indent, elided, plural(e.a, elided))
+			elided = 0
+		}
+	}
+	for _, child := range e.children {
+		if child.equal {
+			elided++
+			continue
+		}
+		flush()
+		r.write(sb, child, depth+1)
+	}
+	flush()
+	fmt.Fprintf(sb, "  %s}%s\n", indent, suffix)
+}
+
+func (r *TextReporter) format(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	} else if r.Format != nil && v.CanInterface() {
+		return r.Format(v.Interface())
+	} else if m, ok := interfaceOf(v).(Matcher); ok {
+		return m.String()
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+// typeName returns the name of the type of the values, marking the pointers with "&".
+func typeName(a reflect.Value, b reflect.Value) string {
+	v := a
+	if !v.IsValid() {
+		v = b
+	}
+	name := ""
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			break
+		} else if v.Kind() == reflect.Ptr {
+			name += "&"
+		}
+		v = v.Elem()
+	}
+	return name + v.Type().String()
+}
+
+// plural returns the name of the children of the value v.
+func plural(v reflect.Value, count int) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	singular, plural := "value", "values"
+	switch v.Kind() {
+	case reflect.Struct:
+		singular, plural = "field", "fields"
+	case reflect.Array, reflect.Slice:
+		singular, plural = "element", "elements"
+	case reflect.Map:
+		singular, plural = "entry", "entries"
+	}
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
+type line struct {
+	mark string
+	text string
+}
+
+// multiline returns the line by line differences of a and b, if both are strings and any of them has multiple lines.
+func multiline(a reflect.Value, b reflect.Value) ([]line, bool) {
+	if !a.IsValid() || !b.IsValid() || a.Kind() != reflect.String || b.Kind() != reflect.String {
+		return nil, false
+	} else if !strings.Contains(a.String(), "\n") && !strings.Contains(b.String(), "\n") {
+		return nil, false
+	}
+
+	la, lb := strings.Split(a.String(), "\n"), strings.Split(b.String(), "\n")
+
+	// The lines are aligned with the shortest edit script, bounded like the slices, or all replaced when it is exceeded.
+	edits, ok := shortestEdit(len(la), len(lb), func(i, j int) bool { return la[i] == lb[j] }, defaultAlignLimit)
+	if !ok {
+		edits = nil
+		for i := range la {
+			edits = append(edits, edit{i, -1})
+		}
+		for j := range lb {
+			edits = append(edits, edit{-1, j})
+		}
+	}
+
+	// The removed lines of each change are written before the added ones.
+	var lines, added []line
+	for _, e := range edits {
+		switch {
+		case e.j < 0:
+			lines = append(lines, line{"-", la[e.i]})
+		case e.i < 0:
+			added = append(added, line{"+", lb[e.j]})
+		default:
+			lines = append(append(lines, added...), line{" ", la[e.i]})
+			added = added[:0]
+		}
+	}
+	lines = append(lines, added...)
+	return lines, true
+}
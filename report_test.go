@@ -0,0 +1,130 @@
+package comparer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es11 struct {
+	Name  string
+	Inner es1
+	Ptr   *es2
+	Tags  []string
+	Count map[string]int
+	Text  string
+}
+
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) Push(n *comparer.Node) {
+	r.events = append(r.events, "push "+n.Path)
+}
+
+func (r *recorder) Pop(equal bool) {
+	r.events = append(r.events, fmt.Sprintf("pop %v", equal))
+}
+
+func TestReport(t *testing.T) {
+	r := &recorder{}
+	c := comparer.New()
+
+	if c.Report(es3{es1{1, "test1"}, &es2{2, "test2"}}, es3{es1{1, "test3"}, &es2{2, "test2"}}, r) {
+		t.Errorf("The values should not be equal")
+	}
+
+	expected := []string{
+		"push ",
+		"push A", "push A.A", "pop true", "push A.B", "pop false", "pop false",
+		"push B", "push B.A", "pop true", "push B.B", "pop true", "pop true",
+		"pop false",
+	}
+	if strings.Join(r.events, ",") != strings.Join(expected, ",") {
+		t.Errorf("The events should be %v, got %v", expected, r.events)
+	}
+}
+
+func TestTextReporter(t *testing.T) {
+	a := es11{"x", es1{1, "a"}, &es2{2, "b"}, []string{"a", "b", "c"}, map[string]int{"a": 1, "b": 2}, "l1\nl2\nl3"}
+	b := es11{"x", es1{1, "z"}, &es2{2, "b"}, []string{"a", "b"}, map[string]int{"a": 1, "c": 3}, "l1\nl2x\nl3"}
+
+	r := &comparer.TextReporter{}
+	comparer.New().Report(a, b, r)
+
+	expected := strings.Join([]string{
+		"  comparer_test.es11{",
+		"  \t... // 1 identical field",
+		"  \tInner: comparer_test.es1{",
+		"  \t\t... // 1 identical field",
+		`- 		B: "a",`,
+		`+ 		B: "z",`,
+		"  \t},",
+		"  \t... // 1 identical field",
+		"  \tTags: []string{",
+		"  \t\t... // 2 identical elements",
+		`- 		[2]: "c",`,
+		"  \t},",
+		"  \tCount: map[string]int{",
+		"  \t\t... // 1 identical entry",
+		"- \t\t[b]: 2,",
+		"+ \t\t[c]: 3,",
+		"  \t},",
+		"  \tText: strings.Join({",
+		`  		"l1",`,
+		`- 		"l2",`,
+		`+ 		"l2x",`,
+		`  		"l3",`,
+		`  	}, "\n"),`,
+		"  }",
+		"",
+	}, "\n")
+	if r.String() != expected {
+		t.Errorf("The report should be\n%s\ngot\n%s", expected, r.String())
+	}
+}
+
+func TestTextReporterFormat(t *testing.T) {
+	r := &comparer.TextReporter{Format: func(v interface{}) string {
+		return fmt.Sprintf("<%v>", v)
+	}}
+	c := comparer.New()
+
+	if !c.Report(es1{1, "a"}, es1{1, "a"}, r) || r.String() != "" {
+		t.Errorf("The report of equal values should be empty, got %q", r.String())
+	}
+
+	r = &comparer.TextReporter{Format: r.Format}
+	c.Report(es3{es1{1, "a"}, nil}, es3{es1{1, "a"}, &es2{2, "b"}}, r)
+	if !strings.Contains(r.String(), "- \tB: <<nil>>,\n+ \tB: <&{2 b}>,\n") {
+		t.Errorf("The report should use the formatter, got\n%s", r.String())
+	}
+}
+
+func TestTextReporterLongText(t *testing.T) {
+	long := func(prefix string, changed int) string {
+		lines := make([]string, 20000)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("%s%d", prefix, i)
+		}
+		if changed >= 0 {
+			lines[changed] = "changed"
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	r := &comparer.TextReporter{}
+	comparer.New().Report(long("a", -1), long("a", 100), r)
+	if lines := strings.Split(r.String(), "\n"); len(lines) != 20004 || lines[101] != `- 	"a100",` || lines[102] != `+ 	"changed",` {
+		t.Errorf("The long texts should be compared line by line, got %d lines", len(lines))
+	}
+
+	r = &comparer.TextReporter{}
+	comparer.New().Report(long("a", -1), long("b", -1), r)
+	if lines := strings.Split(r.String(), "\n"); len(lines) != 40003 || lines[1] != `- 	"a0",` || lines[20001] != `+ 	"b0",` {
+		t.Errorf("The long texts over the limit should be replaced, got %d lines", len(lines))
+	}
+}
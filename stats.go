@@ -0,0 +1,80 @@
+package comparer
+
+import "strings"
+
+// Exhaustive returns a new Config that compares all the values, instead of stopping at the first difference.
+// It makes the NodeComparators and the Reporters receive all the nodes, at the cost of comparing values that do not change the result.
+func Exhaustive() Config {
+	return func(comp *Comparer) {
+		comp.exhaustive = true
+	}
+}
+
+// Stats summarizes the differences between two values.
+type Stats struct {
+	// Nodes is the number of compared values. The pointers and interfaces are followed without counting them.
+	Nodes int
+	// Leaves is the number of values compared without comparing their children, like numbers, strings or the values compared by a Comparator.
+	Leaves int
+	// EqualLeaves is the number of leaves that are equal.
+	EqualLeaves int
+	// Modified, Added and Removed are the number of differences of each kind.
+	Modified, Added, Removed int
+	// Paths is the number of differences of each path, where the indexes and the keys are replaced by "[*]", like "Users[*].Name".
+	Paths map[string]int
+}
+
+// Stats compares all the values of a and b, and returns the statistics of their differences.
+func (c *Comparer) Stats(a interface{}, b interface{}) Stats {
+	n := c.root(EqualOperation, a, b)
+	n.state = &state{exhaustive: true, report: true}
+	c.equalRoot(n, nil)
+
+	s := Stats{Nodes: n.state.visited, Leaves: n.state.leaves, EqualLeaves: n.state.equalLeaves, Paths: map[string]int{}}
+	for _, d := range n.state.diffs {
+		switch d.Change {
+		case Added:
+			s.Added++
+		case Removed:
+			s.Removed++
+		default:
+			s.Modified++
+		}
+		s.Paths[pattern(d.Steps)]++
+	}
+	return s
+}
+
+// Differences returns the number of differences.
+func (s Stats) Differences() int {
+	return s.Modified + s.Added + s.Removed
+}
+
+// Similarity returns the ratio of leaves that are equal, from 0 when all of them are different to 1 when the values are equal.
+func (s Stats) Similarity() float64 {
+	if s.Leaves == 0 {
+		return 1
+	}
+	return float64(s.EqualLeaves) / float64(s.Leaves)
+}
+
+// pattern returns the path of the steps, with the indexes and the keys replaced by "[*]".
+func pattern(steps []Step) string {
+	var sb strings.Builder
+	for _, s := range steps {
+		switch s.Kind {
+		case FieldStep:
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(s.Name)
+		case IndexStep, KeyStep:
+			sb.WriteString("[*]")
+		case TransformStep:
+			sb.WriteString("{" + s.Name + "}")
+		default:
+			sb.WriteString(s.Name)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,76 @@
+package comparer_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+func TestStats(t *testing.T) {
+	cases := map[string]struct {
+		a          interface{}
+		b          interface{}
+		expected   comparer.Stats
+		similarity float64
+	}{
+		"Equal": {
+			es1{1, "a"},
+			es1{1, "a"},
+			comparer.Stats{Nodes: 3, Leaves: 2, EqualLeaves: 2, Paths: map[string]int{}},
+			1,
+		},
+		"Struct": {
+			es3{es1{1, "a"}, &es2{2, "b"}},
+			es3{es1{1, "x"}, &es2{3, "y"}},
+			comparer.Stats{Nodes: 7, Leaves: 4, EqualLeaves: 1, Modified: 3, Paths: map[string]int{"A.B": 1, "B.A": 1, "B.B": 1}},
+			0.25,
+		},
+		"Slice": {
+			[]es1{{1, "a"}, {2, "b"}, {3, "c"}},
+			[]es1{{1, "x"}, {2, "y"}},
+			comparer.Stats{Nodes: 8, Leaves: 5, EqualLeaves: 2, Modified: 2, Removed: 1, Paths: map[string]int{"[*].B": 2, "[*]": 1}},
+			0.4,
+		},
+		"Map": {
+			map[string]int{"a": 1, "b": 2},
+			map[string]int{"a": 1, "c": 3},
+			comparer.Stats{Nodes: 4, Leaves: 3, EqualLeaves: 1, Added: 1, Removed: 1, Paths: map[string]int{"[*]": 2}},
+			1.0 / 3,
+		},
+	}
+
+	c := comparer.New()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := c.Stats(tc.a, tc.b)
+			if !reflect.DeepEqual(s, tc.expected) {
+				t.Errorf("The statistics should be %+v, got %+v", tc.expected, s)
+			}
+			if s.Similarity() != tc.similarity {
+				t.Errorf("The similarity should be %v, got %v", tc.similarity, s.Similarity())
+			}
+			if s.Differences() != len(c.Diff(tc.a, tc.b)) {
+				t.Errorf("The number of differences should agree with Diff")
+			}
+		})
+	}
+}
+
+func TestExhaustive(t *testing.T) {
+	var paths []string
+	record := comparer.CustomNodeComparator(func(n *comparer.Node, a, b interface{}) (int, bool) {
+		paths = append(paths, n.Path)
+		return 0, false
+	})
+
+	a, b := []int{1, 2, 3}, []int{0, 2, 0}
+	if comparer.New(record).Equal(a, b); len(paths) != 2 {
+		t.Errorf("The comparison should stop at the first difference, got %v", paths)
+	}
+
+	paths = nil
+	if comparer.New(record, comparer.Exhaustive()).Equal(a, b); len(paths) != 4 {
+		t.Errorf("The comparison should visit all the values, got %v", paths)
+	}
+}
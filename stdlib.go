@@ -0,0 +1,237 @@
+package comparer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StdlibComparators returns a new Config that applies all the comparators for the standard library types.
+// The time.Time values are compared without truncation.
+func StdlibComparators() Config {
+	return combine(
+		TimeComparator(0),
+		DurationComparator(),
+		BigComparator(),
+		IPComparator(),
+		URLComparator(),
+		RegexpComparator(),
+		RawMessageComparator(),
+		BytesComparator(),
+	)
+}
+
+// TimeComparator returns a new Config that compares the time.Time values by the instant they represent, ignoring the location.
+// If truncate is greater than zero, both values are truncated to a multiple of truncate before the comparison.
+func TimeComparator(truncate time.Duration) Config {
+	return TypeComparator(reflect.TypeOf(time.Time{}), func(_ string, a interface{}, b interface{}) (int, bool) {
+		ta, tb := a.(time.Time), b.(time.Time)
+		if truncate > 0 {
+			ta, tb = ta.Truncate(truncate), tb.Truncate(truncate)
+		}
+		if ta.Before(tb) {
+			return -1, true
+		} else if ta.After(tb) {
+			return 1, true
+		} else {
+			return 0, true
+		}
+	})
+}
+
+// DurationComparator returns a new Config that compares the time.Duration values.
+func DurationComparator() Config {
+	return TypeComparator(reflect.TypeOf(time.Duration(0)), func(_ string, a interface{}, b interface{}) (int, bool) {
+		da, db := a.(time.Duration), b.(time.Duration)
+		if da < db {
+			return -1, true
+		} else if da > db {
+			return 1, true
+		} else {
+			return 0, true
+		}
+	})
+}
+
+// BigComparator returns a new Config that compares the *big.Int, *big.Float and *big.Rat values by their numeric value.
+// The nil pointers are left to the default comparison.
+func BigComparator() Config {
+	return combine(
+		TypeComparator(reflect.TypeOf((*big.Int)(nil)), func(_ string, a interface{}, b interface{}) (int, bool) {
+			ia, ib := a.(*big.Int), b.(*big.Int)
+			if ia == nil || ib == nil {
+				return 0, false
+			}
+			return ia.Cmp(ib), true
+		}),
+		TypeComparator(reflect.TypeOf((*big.Float)(nil)), func(_ string, a interface{}, b interface{}) (int, bool) {
+			fa, fb := a.(*big.Float), b.(*big.Float)
+			if fa == nil || fb == nil {
+				return 0, false
+			}
+			return fa.Cmp(fb), true
+		}),
+		TypeComparator(reflect.TypeOf((*big.Rat)(nil)), func(_ string, a interface{}, b interface{}) (int, bool) {
+			ra, rb := a.(*big.Rat), b.(*big.Rat)
+			if ra == nil || rb == nil {
+				return 0, false
+			}
+			return ra.Cmp(rb), true
+		}),
+	)
+}
+
+// IPComparator returns a new Config that compares the net.IP and netip.Addr values.
+// The IPv4 addresses are equal to their IPv4-in-IPv6 form when they are represented as net.IP values.
+func IPComparator() Config {
+	return combine(
+		TypeComparator(reflect.TypeOf(net.IP{}), func(_ string, a interface{}, b interface{}) (int, bool) {
+			ia, ib := a.(net.IP), b.(net.IP)
+			if ia.To16() != nil && ib.To16() != nil {
+				ia, ib = ia.To16(), ib.To16()
+			}
+			return bytes.Compare(ia, ib), true
+		}),
+		TypeComparator(reflect.TypeOf(netip.Addr{}), func(_ string, a interface{}, b interface{}) (int, bool) {
+			return a.(netip.Addr).Compare(b.(netip.Addr)), true
+		}),
+	)
+}
+
+// URLComparator returns a new Config that compares the url.URL and *url.URL values by their string representation.
+// The nil pointers are left to the default comparison.
+func URLComparator() Config {
+	return combine(
+		TypeComparator(reflect.TypeOf(url.URL{}), func(_ string, a interface{}, b interface{}) (int, bool) {
+			ua, ub := a.(url.URL), b.(url.URL)
+			return strings.Compare(ua.String(), ub.String()), true
+		}),
+		TypeComparator(reflect.TypeOf((*url.URL)(nil)), func(_ string, a interface{}, b interface{}) (int, bool) {
+			ua, ub := a.(*url.URL), b.(*url.URL)
+			if ua == nil || ub == nil {
+				return 0, false
+			}
+			return strings.Compare(ua.String(), ub.String()), true
+		}),
+	)
+}
+
+// RegexpComparator returns a new Config that compares the *regexp.Regexp values by their source pattern.
+// The nil pointers are left to the default comparison.
+func RegexpComparator() Config {
+	return TypeComparator(reflect.TypeOf((*regexp.Regexp)(nil)), func(_ string, a interface{}, b interface{}) (int, bool) {
+		ra, rb := a.(*regexp.Regexp), b.(*regexp.Regexp)
+		if ra == nil || rb == nil {
+			return 0, false
+		}
+		return strings.Compare(ra.String(), rb.String()), true
+	})
+}
+
+// RawMessageComparator returns a new Config that compares the json.RawMessage values by the JSON value they encode.
+// The numbers are compared exactly, so the large integers that do not fit in a float64 are not equal.
+// The values that are not valid JSON, or that have numbers with exponents beyond ±1000, are left to the default comparison.
+func RawMessageComparator() Config {
+	return TypeComparator(reflect.TypeOf(json.RawMessage{}), func(_ string, a interface{}, b interface{}) (int, bool) {
+		ja, ok := canonicalJSON(a.(json.RawMessage))
+		if !ok {
+			return 0, false
+		}
+
+		jb, ok := canonicalJSON(b.(json.RawMessage))
+		if !ok {
+			return 0, false
+		}
+
+		return bytes.Compare(ja, jb), true
+	})
+}
+
+// BytesComparator returns a new Config that compares the byte slices and arrays byte-wise, including the named byte types.
+// The nil and empty slices are equal.
+// If no paths are provided, it applies to all the byte slices and arrays.
+func BytesComparator(paths ...string) Config {
+	return func(comp *Comparer) {
+		comp.bytes = append(comp.bytes, paths)
+	}
+}
+
+// maxExponent is the maximum exponent of the numbers of the JSON values, that bounds the size of their exact representation.
+const maxExponent = 1000
+
+func canonicalJSON(m json.RawMessage) ([]byte, bool) {
+	d := json.NewDecoder(bytes.NewReader(m))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, false
+	} else if _, err := d.Token(); err != io.EOF {
+		return nil, false
+	}
+
+	v, ok := canonicalNumbers(v)
+	if !ok {
+		return nil, false
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// canonicalNumbers replaces the numbers of the JSON value v by their exact representation, so the equal numbers are encoded in the same way.
+func canonicalNumbers(v interface{}) (interface{}, bool) {
+	var ok bool
+	switch x := v.(type) {
+	case json.Number:
+		return canonicalNumber(x)
+	case []interface{}:
+		for i := range x {
+			if x[i], ok = canonicalNumbers(x[i]); !ok {
+				return nil, false
+			}
+		}
+	case map[string]interface{}:
+		for k := range x {
+			if x[k], ok = canonicalNumbers(x[k]); !ok {
+				return nil, false
+			}
+		}
+	}
+	return v, true
+}
+
+// canonicalNumber returns the number n as an integer or as a decimal without trailing zeros, like 100 for 1e2 or 0.5 for 5e-1.
+func canonicalNumber(n json.Number) (json.Number, bool) {
+	s := string(n)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		if e, err := strconv.Atoi(s[i+1:]); err != nil || e > maxExponent || e < -maxExponent {
+			return "", false
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return "", false
+	} else if r.IsInt() {
+		return json.Number(r.Num().String()), true
+	}
+
+	// The denominator of a decimal number divides a power of 10, that gives the number of decimals of its exact representation.
+	decimals := 0
+	ten := big.NewInt(10)
+	for p := big.NewInt(1); new(big.Int).Mod(p, r.Denom()).Sign() != 0; decimals++ {
+		p.Mul(p, ten)
+	}
+	return json.Number(r.FloatString(decimals)), true
+}
@@ -0,0 +1,219 @@
+package comparer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es4 struct {
+	A time.Time
+	B *big.Int
+	C net.IP
+}
+
+var (
+	t1 = time.Date(2021, 7, 6, 10, 0, 0, 0, time.UTC)
+	t2 = time.Date(2021, 7, 6, 10, 0, 0, 500, time.UTC)
+	t3 = time.Date(2021, 7, 6, 11, 0, 0, 0, time.UTC)
+)
+
+func mustURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+var sdifferent = map[string][]dtc{
+	"Time": {
+		{t1, t3, true},
+		{t2, t3, true},
+		{t1, t2, true},
+	},
+	"Duration": {
+		{time.Second, time.Minute, true},
+	},
+	"BigInt": {
+		{big.NewInt(1), big.NewInt(2), true},
+	},
+	"BigFloat": {
+		{big.NewFloat(1.5), big.NewFloat(2), true},
+	},
+	"BigRat": {
+		{big.NewRat(1, 3), big.NewRat(1, 2), true},
+	},
+	"IP": {
+		{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"), true},
+		{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2"), true},
+	},
+	"URL": {
+		{*mustURL("http://a.example"), *mustURL("http://b.example"), true},
+		{mustURL("http://a.example"), mustURL("http://b.example"), true},
+	},
+	"Regexp": {
+		{regexp.MustCompile("^a"), regexp.MustCompile("^b"), true},
+	},
+	"RawMessage": {
+		{json.RawMessage(`{"a":1}`), json.RawMessage(`{"a":2}`), true},
+		{json.RawMessage(`12345678901234567890`), json.RawMessage(`12345678901234567891`), true},
+		{json.RawMessage(`0.10000000000000001`), json.RawMessage(`0.10000000000000002`), true},
+	},
+	"Bytes": {
+		{[]byte("a"), []byte("b"), true},
+		{[]byte("a"), []byte("ab"), true},
+	},
+	"Struct": {
+		{es4{t1, big.NewInt(1), net.ParseIP("10.0.0.1")}, es4{t3, big.NewInt(1), net.ParseIP("10.0.0.1")}, false},
+		{es4{t1, big.NewInt(1), net.ParseIP("10.0.0.1")}, es4{t1, big.NewInt(2), net.ParseIP("10.0.0.1")}, false},
+	},
+}
+
+var sequal = map[string][]etc{
+	"Time": {
+		{t1, t1.In(time.FixedZone("UTC-3", -3*60*60)), true},
+	},
+	"Duration": {
+		{time.Second, 1000 * time.Millisecond, true},
+	},
+	"BigInt": {
+		{big.NewInt(10), new(big.Int).SetBytes([]byte{10}), true},
+	},
+	"BigFloat": {
+		{big.NewFloat(1.5), new(big.Float).SetPrec(200).SetFloat64(1.5), true},
+	},
+	"BigRat": {
+		{big.NewRat(1, 2), big.NewRat(2, 4), true},
+	},
+	"IP": {
+		{net.ParseIP("10.0.0.1"), net.IPv4(10, 0, 0, 1).To4(), true},
+		{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.1"), true},
+	},
+	"URL": {
+		{*mustURL("http://a.example/path"), *mustURL("http://a.example/path"), true},
+		{mustURL("http://a.example/path"), mustURL("http://a.example/path"), true},
+	},
+	"Regexp": {
+		{regexp.MustCompile("^a"), regexp.MustCompile("^a"), true},
+	},
+	"RawMessage": {
+		{json.RawMessage(`{"a":1,"b":[1,2]}`), json.RawMessage(`{ "b": [1, 2], "a": 1.0 }`), true},
+		{json.RawMessage(`[1e2, 0.50, -2.5E-1]`), json.RawMessage(`[100, 5e-1, -0.25]`), true},
+		{json.RawMessage(`12345678901234567890`), json.RawMessage(`1234567890123456789e1`), true},
+	},
+	"Bytes": {
+		{[]byte("a"), []byte("a"), true},
+	},
+	"Struct": {
+		{es4{t1, big.NewInt(1), net.ParseIP("10.0.0.1")}, es4{t1.Local(), big.NewInt(1), net.IPv4(10, 0, 0, 1).To4()}, false},
+	},
+}
+
+func TestStdlibCompare(t *testing.T) {
+	c := comparer.New(comparer.StdlibComparators())
+
+	run := func(t *testing.T, a interface{}, b interface{}, expected int, isComparable bool) {
+		comparison, comparable := c.Compare(a, b)
+		if comparable != isComparable {
+			if isComparable {
+				t.Errorf("The values should be comparable")
+			} else {
+				t.Errorf("The values should not be comparable")
+			}
+		} else if comparable && comparison != expected {
+			if expected < 0 {
+				t.Errorf("The value %+v shoud be greater than the value %+v", b, a)
+			} else if expected > 0 {
+				t.Errorf("The value %+v shoud be greater than the value %+v", a, b)
+			} else {
+				t.Errorf("The values should be equal")
+			}
+		}
+	}
+
+	for name, cases := range sdifferent {
+		t.Run(name, func(t *testing.T) {
+			for _, tc := range cases {
+				t.Run(fmt.Sprintf("comparer.Compare(%+v,%+v)", tc.min, tc.max), func(t *testing.T) {
+					run(t, tc.min, tc.max, -1, tc.comparable)
+				})
+				t.Run(fmt.Sprintf("comparer.Compare(%+v,%+v)", tc.max, tc.min), func(t *testing.T) {
+					run(t, tc.max, tc.min, 1, tc.comparable)
+				})
+			}
+		})
+	}
+
+	for name, cases := range sequal {
+		t.Run(name, func(t *testing.T) {
+			for _, tc := range cases {
+				t.Run(fmt.Sprintf("comparer.Compare(%+v,%+v)", tc.a, tc.b), func(t *testing.T) {
+					run(t, tc.a, tc.b, 0, tc.comparable)
+				})
+				t.Run(fmt.Sprintf("comparer.Compare(%+v,%+v)", tc.b, tc.a), func(t *testing.T) {
+					run(t, tc.b, tc.a, 0, tc.comparable)
+				})
+			}
+		})
+	}
+}
+
+func TestStdlibEqual(t *testing.T) {
+	c := comparer.New(comparer.StdlibComparators())
+
+	run := func(t *testing.T, a interface{}, b interface{}, equal bool) {
+		if c.Equal(a, b) != equal {
+			if equal {
+				t.Errorf("The values should be equal")
+			} else {
+				t.Errorf("The values should not be equal")
+			}
+		}
+	}
+
+	for name, cases := range sdifferent {
+		t.Run(name, func(t *testing.T) {
+			for _, tc := range cases {
+				t.Run(fmt.Sprintf("comparer.Equal(%+v,%+v)", tc.min, tc.max), func(t *testing.T) {
+					run(t, tc.min, tc.max, false)
+				})
+				t.Run(fmt.Sprintf("comparer.Equal(&%+v,&%+v)", tc.min, tc.max), func(t *testing.T) {
+					run(t, &tc.min, &tc.max, false)
+				})
+			}
+		})
+	}
+
+	for name, cases := range sequal {
+		t.Run(name, func(t *testing.T) {
+			for _, tc := range cases {
+				t.Run(fmt.Sprintf("comparer.Equal(%+v,%+v)", tc.a, tc.b), func(t *testing.T) {
+					run(t, tc.a, tc.b, true)
+				})
+				t.Run(fmt.Sprintf("comparer.Equal(&%+v,&%+v)", tc.a, tc.b), func(t *testing.T) {
+					run(t, &tc.a, &tc.b, true)
+				})
+			}
+		})
+	}
+}
+
+func TestTimeComparatorTruncate(t *testing.T) {
+	c := comparer.New(comparer.TimeComparator(time.Second))
+
+	if !c.Equal(t1, t2) {
+		t.Errorf("The values should be equal")
+	}
+	if c.Equal(t1, t3) {
+		t.Errorf("The values should not be equal")
+	}
+}
@@ -0,0 +1,48 @@
+package comparer
+
+import "reflect"
+
+// Subset returns a new Config that checks if the first value is contained in the second one, instead of checking if they are equal.
+//
+// The second value can have extra map entries and extra slice elements, that must contain the elements of the first value in the same order.
+// The zero struct fields of the first value match any value.
+func Subset() Config {
+	return func(comp *Comparer) {
+		comp.subset = true
+	}
+}
+
+// Contains reports whether actual contains expected, following the rules of the Subset configuration.
+// The Diff method of a Comparer with the Subset configuration shows the parts of the expected value that are missing.
+func (c *Comparer) Contains(expected interface{}, actual interface{}) bool {
+	n := c.root(EqualOperation, expected, actual)
+	n.state = &state{subset: true}
+	contained, _ := c.equalRoot(n, nil)
+	return contained
+}
+
+// contains reports whether the elements of the slice a are contained in the slice b, in the same order.
+// The elements that can not be found are reported as removed.
+func (c *Comparer) contains(n *Node) bool {
+	a, b := n.A, n.B
+	ok := true
+	j := 0
+	for i := 0; i < a.Len() && n.proceed(ok); i++ {
+		found := false
+		for k := j; k < b.Len() && !found; k++ {
+			probe := n.indexChild(i, a.Index(i), b.Index(k))
+			probe.state = n.probe()
+			probe.state.subset = true
+			if c.equal(probe) {
+				found = true
+				j = k + 1
+			}
+		}
+		if !found {
+			missing := n.indexChild(i, a.Index(i), reflect.Value{})
+			missing.entry = true
+			ok = c.equal(missing) && ok
+		}
+	}
+	return ok
+}
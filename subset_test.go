@@ -0,0 +1,68 @@
+package comparer_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es9 struct {
+	ID     int
+	Name   string
+	Tags   []string
+	Labels map[string]string
+}
+
+func TestContains(t *testing.T) {
+	cases := map[string]struct {
+		expected interface{}
+		actual   interface{}
+		contains bool
+	}{
+		"Equal":         {es9{1, "a", []string{"x"}, map[string]string{"k": "v"}}, es9{1, "a", []string{"x"}, map[string]string{"k": "v"}}, true},
+		"ZeroFields":    {es9{Name: "a"}, es9{1, "a", []string{"x"}, map[string]string{"k": "v"}}, true},
+		"ZeroDifferent": {es9{Name: "b"}, es9{1, "a", nil, nil}, false},
+		"ExtraKeys":     {map[string]int{"a": 1}, map[string]int{"a": 1, "b": 2}, true},
+		"MissingKeys":   {map[string]int{"a": 1, "c": 3}, map[string]int{"a": 1, "b": 2}, false},
+		"NilMap":        {map[string]int(nil), map[string]int{"a": 1}, true},
+		"ExtraElements": {[]int{1, 3}, []int{0, 1, 2, 3, 4}, true},
+		"Order":         {[]int{3, 1}, []int{1, 2, 3}, false},
+		"Missing":       {[]int{1, 5}, []int{1, 2, 3}, false},
+		"Nested":        {[]es9{{Name: "b", Labels: map[string]string{"k": "v"}}}, []es9{{1, "a", nil, nil}, {2, "b", nil, map[string]string{"k": "v", "l": "w"}}}, true},
+		"Reverse":       {map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1}, false},
+	}
+
+	c := comparer.New()
+	s := comparer.New(comparer.Subset())
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if c.Contains(tc.expected, tc.actual) != tc.contains {
+				if tc.contains {
+					t.Errorf("The actual value should contain the expected value")
+				} else {
+					t.Errorf("The actual value should not contain the expected value")
+				}
+			}
+			if s.Equal(tc.expected, tc.actual) != tc.contains {
+				t.Errorf("The Subset configuration should agree with Contains")
+			}
+		})
+	}
+}
+
+func TestSubsetDiff(t *testing.T) {
+	c := comparer.New(comparer.Subset())
+
+	expected := es9{Name: "a", Tags: []string{"x", "z"}, Labels: map[string]string{"k": "v", "m": "n"}}
+	actual := es9{1, "a", []string{"w", "x", "y"}, map[string]string{"k": "v", "l": "w"}}
+
+	diffs := summary(c.Diff(expected, actual))
+	missing := []comparer.Difference{
+		{Path: "Tags[1]", Change: comparer.Removed, A: "z", B: nil},
+		{Path: "Labels[m]", Change: comparer.Removed, A: "n", B: nil},
+	}
+	if !reflect.DeepEqual(diffs, missing) {
+		t.Errorf("The differences should be %+v, got %+v", missing, diffs)
+	}
+}
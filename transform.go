@@ -0,0 +1,59 @@
+package comparer
+
+import "reflect"
+
+// A Transformer maps a value into another value that is compared instead of it.
+type Transformer func(v interface{}) interface{}
+
+type transformer struct {
+	name  string
+	t     reflect.Type
+	f     Transformer
+	paths scope
+}
+
+// Transform returns a new Config that compares the values of type t through the Transformer f.
+// If t is nil, it applies to the values of any type. If no paths are provided, it applies to all the paths.
+//
+// The name is added to the path of the transformed values, like "Tags{sort}".
+// The transformers are applied before the comparators. A transformer is not applied again to its own output, nor to the values of
+// the type it transformed inside its output, so it must transform the nested values of a recursive type by itself.
+// The paths of the other configurations match the paths of the transformed values with or without the names of the transformers.
+func Transform(name string, t reflect.Type, f Transformer, paths ...string) Config {
+	return func(comp *Comparer) {
+		comp.trans = append(comp.trans, transformer{name: name, t: t, f: f, paths: paths})
+	}
+}
+
+// transform returns the node of the transformed values, if any transformer applies to the node.
+func (c *Comparer) transform(n *Node) (*Node, bool) {
+	for i, t := range c.trans {
+		if n.transformed(i) || !t.paths.match(n.Path) {
+			continue
+		} else if t.t != nil && (n.A.Type() != t.t || n.B.Type() != t.t) {
+			continue
+		}
+
+		m := n.elem(reflect.ValueOf(t.f(c.value(n.A))), reflect.ValueOf(t.f(c.value(n.B))))
+		m.Path += "{" + t.name + "}"
+		m.indirect = false
+		m.Parent = n
+		if n.detailed() {
+			m.step = &Step{Kind: TransformStep, Name: t.name}
+		}
+		m.transformer = i + 1
+		return m, true
+	}
+	return nil, false
+}
+
+// transformed reports whether the transformer i produced the node, or a value of its type in the chain of its parents.
+// It keeps a transformer from being applied again to its own output, like a transformer that wraps its input in a slice.
+func (n *Node) transformed(i int) bool {
+	for m := n; m != nil; m = m.Parent {
+		if m.transformer == i+1 && (m == n || m.Parent.A.Type() == n.A.Type()) {
+			return true
+		}
+	}
+	return false
+}
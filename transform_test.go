@@ -0,0 +1,158 @@
+package comparer_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gum-dev-ar/comparer"
+)
+
+type es7 struct {
+	ID   int
+	Name string
+	Tags []string
+}
+
+func TestTransform(t *testing.T) {
+	sorted := func(v interface{}) interface{} {
+		s := append([]string(nil), v.([]string)...)
+		sort.Strings(s)
+		return s
+	}
+	trim := func(v interface{}) interface{} {
+		return strings.TrimSpace(v.(string))
+	}
+	id := func(v interface{}) interface{} {
+		return v.(es7).ID
+	}
+	parse := func(v interface{}) interface{} {
+		var p interface{}
+		if err := json.Unmarshal(v.(json.RawMessage), &p); err != nil {
+			return v
+		}
+		return p
+	}
+
+	cases := map[string]struct {
+		config comparer.Config
+		a      interface{}
+		b      interface{}
+		equal  bool
+	}{
+		"SortEqual":     {comparer.Transform("sort", reflect.TypeOf([]string{}), sorted), es7{1, "a", []string{"x", "y"}}, es7{1, "a", []string{"y", "x"}}, true},
+		"SortDifferent": {comparer.Transform("sort", reflect.TypeOf([]string{}), sorted), es7{1, "a", []string{"x", "y"}}, es7{1, "a", []string{"y", "z"}}, false},
+		"TrimEqual":     {comparer.Transform("trim", reflect.TypeOf(""), trim), es7{1, " a ", nil}, es7{1, "a", nil}, true},
+		"TrimPath":      {comparer.Transform("trim", reflect.TypeOf(""), trim, "Tags"), es7{1, "a", []string{" x"}}, es7{1, "a", []string{"x"}}, true},
+		"TrimOtherPath": {comparer.Transform("trim", reflect.TypeOf(""), trim, "Tags"), es7{1, " a", nil}, es7{1, "a", nil}, false},
+		"IDEqual":       {comparer.Transform("id", reflect.TypeOf(es7{}), id), []es7{{1, "a", nil}}, []es7{{1, "b", nil}}, true},
+		"IDDifferent":   {comparer.Transform("id", reflect.TypeOf(es7{}), id), []es7{{1, "a", nil}}, []es7{{2, "a", nil}}, false},
+		"JSONEqual":     {comparer.Transform("json", reflect.TypeOf(json.RawMessage{}), parse), json.RawMessage(`{"a":[1,2]}`), json.RawMessage(`{ "a": [1, 2] }`), true},
+		"JSONDifferent": {comparer.Transform("json", reflect.TypeOf(json.RawMessage{}), parse), json.RawMessage(`{"a":[1,2]}`), json.RawMessage(`{"a":[2,1]}`), false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := comparer.New(tc.config)
+			if c.Equal(tc.a, tc.b) != tc.equal {
+				if tc.equal {
+					t.Errorf("The values should be equal")
+				} else {
+					t.Errorf("The values should not be equal")
+				}
+			}
+		})
+	}
+}
+
+func TestTransformCompare(t *testing.T) {
+	length := func(v interface{}) interface{} {
+		return len(v.(string))
+	}
+	c := comparer.New(comparer.Transform("len", reflect.TypeOf(""), length))
+
+	if comparison, comparable := c.Compare("bb", "a"); !comparable || comparison != 1 {
+		t.Errorf("The value %+v shoud be greater than the value %+v", "bb", "a")
+	}
+	if comparison, comparable := c.Compare("b", "a"); !comparable || comparison != 0 {
+		t.Errorf("The values should be equal")
+	}
+}
+
+func TestTransformPath(t *testing.T) {
+	var paths []string
+	recorder := func(n *comparer.Node, a interface{}, b interface{}) (int, bool) {
+		paths = append(paths, n.Path)
+		return 0, false
+	}
+	upper := func(v interface{}) interface{} {
+		return strings.ToUpper(v.(string))
+	}
+	c := comparer.New(
+		comparer.Transform("upper", reflect.TypeOf(""), upper),
+		comparer.Transform("trim", nil, func(v interface{}) interface{} {
+			if s, ok := v.(string); ok {
+				return strings.TrimSpace(s)
+			}
+			return v
+		}, "Name"),
+		comparer.CustomNodeComparator(recorder),
+	)
+
+	if !c.Equal(es7{1, " a ", nil}, es7{1, "A", nil}) {
+		t.Errorf("The values should be equal")
+	}
+
+	expected := ",ID,Name{upper}{trim},Tags"
+	if strings.Join(paths, ",") != expected {
+		t.Errorf("The paths should be %s, got %s", expected, strings.Join(paths, ","))
+	}
+}
+
+func TestTransformScopes(t *testing.T) {
+	trim := comparer.Transform("trim", nil, func(v interface{}) interface{} {
+		if s, ok := v.(string); ok {
+			return strings.TrimSpace(s)
+		}
+		return v
+	})
+
+	cases := map[string]comparer.Config{
+		"FoldCase": comparer.FoldCase("Name"),
+		"Matcher":  comparer.PathMatcher("Name", comparer.Any()),
+		"Scoped":   comparer.Transform("lower", reflect.TypeOf(""), func(v interface{}) interface{} { return strings.ToLower(v.(string)) }, "Name"),
+	}
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			if !comparer.New(config).Equal(es7{1, "A", nil}, es7{1, "a", nil}) {
+				t.Fatalf("The configuration should apply without the transformer")
+			}
+			if !comparer.New(trim, config).Equal(es7{1, " A", nil}, es7{1, "a", nil}) {
+				t.Errorf("The configuration should apply to the transformed paths")
+			}
+		})
+	}
+}
+
+func TestTransformRecursion(t *testing.T) {
+	wrap := comparer.Transform("wrap", nil, func(v interface{}) interface{} {
+		return []interface{}{v}
+	})
+	nested := comparer.Transform("nested", nil, func(v interface{}) interface{} {
+		return []interface{}{[]interface{}{v}}
+	})
+
+	for name, config := range map[string]comparer.Config{"Wrap": wrap, "Nested": nested} {
+		t.Run(name, func(t *testing.T) {
+			c := comparer.New(config)
+			if !c.Equal(es7{1, "a", nil}, es7{1, "a", nil}) {
+				t.Errorf("The values should be equal")
+			}
+			if c.Equal(es7{1, "a", nil}, es7{1, "b", nil}) {
+				t.Errorf("The values should not be equal")
+			}
+		})
+	}
+}